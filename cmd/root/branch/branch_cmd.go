@@ -10,5 +10,7 @@ func Cmd() *cobra.Command {
 		Short: "Branch utilities",
 	}
 	cmd.AddCommand(listCmd())
+	cmd.AddCommand(describeCmd())
+	cmd.AddCommand(renameCmd())
 	return cmd
 }
@@ -0,0 +1,105 @@
+package branch
+
+import (
+	"fmt"
+	"os"
+
+	"gitflow/internal/cli"
+	"gitflow/internal/ui"
+	"gitflow/internal/workflow"
+
+	"github.com/spf13/cobra"
+)
+
+func describeCmd() *cobra.Command {
+	var message string
+	var issue string
+	var parent string
+	var show bool
+
+	cmd := &cobra.Command{
+		Use:   "describe [branch]",
+		Short: "Show or edit a branch's description, issue and parent",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := cli.CommonFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			repoPath, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+
+			opts := workflow.DescribeBranchOptions{RepoPath: repoPath}
+			if len(args) > 0 {
+				opts.Branch = args[0]
+			}
+			if cmd.Flags().Changed("message") {
+				opts.Description = &message
+			}
+			if cmd.Flags().Changed("issue") {
+				opts.Issue = &issue
+			}
+			if cmd.Flags().Changed("parent") {
+				opts.Parent = &parent
+			}
+
+			cfg := c.ConfigResult.Config
+			edit := opts.Description == nil && opts.Issue == nil && opts.Parent == nil && !show
+			if edit {
+				current, err := workflow.DescribeBranch(cfg, opts)
+				if err != nil {
+					return err
+				}
+				in, err := ui.PromptBranchMeta(current.Branch, ui.BranchMetaInput{
+					Description: current.Meta.Description,
+					Issue:       current.Meta.Issue,
+					Parent:      current.Meta.Parent,
+				})
+				if err != nil {
+					return err
+				}
+				opts.Branch = current.Branch
+				opts.Description = &in.Description
+				opts.Issue = &in.Issue
+				opts.Parent = &in.Parent
+			}
+
+			out, err := workflow.DescribeBranch(cfg, opts)
+			if err != nil {
+				return err
+			}
+
+			c.UI.Header("Branch " + out.Branch)
+			if out.Meta.Empty() {
+				c.UI.Line("No metadata recorded")
+				return nil
+			}
+			if out.Meta.Description != "" {
+				c.UI.Line("%s", out.Meta.Description)
+				c.UI.Line("")
+			}
+			if out.Meta.Issue != "" {
+				c.UI.Line("Issue: %s", out.Meta.Issue)
+			}
+			if out.Meta.Parent != "" {
+				c.UI.Line("Parent: %s", out.Meta.Parent)
+			}
+			if !out.Meta.Created.IsZero() {
+				c.UI.Line("Created: %s", out.Meta.Created.Local().Format("2006-01-02 15:04"))
+			}
+			if out.Changed {
+				c.UI.Success("Metadata updated")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Set the description; its first line becomes the PR title")
+	cmd.Flags().StringVar(&issue, "issue", "", "Set the linked issue")
+	cmd.Flags().StringVar(&parent, "parent", "", "Set the parent branch")
+	cmd.Flags().BoolVar(&show, "show", false, "Print the metadata without editing it")
+	return cmd
+}
@@ -13,17 +13,39 @@ import (
 )
 
 func listCmd() *cobra.Command {
-	var base string
+	var (
+		base     string
+		remote   bool
+		all      bool
+		mine     bool
+		stale    int
+		merged   bool
+		prefix   string
+		sortBy   string
+		prs      bool
+		jsonFlag bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List local branches with age and ahead behind counts",
+		Short: "List branches with age and ahead behind counts",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			repoPath, err := os.Getwd()
 			if err != nil {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
 
+			if remote && all {
+				return fmt.Errorf("choose only one of --remote or --all")
+			}
+			scope := workflow.BranchScopeLocal
+			if remote {
+				scope = workflow.BranchScopeRemote
+			}
+			if all {
+				scope = workflow.BranchScopeAll
+			}
+
 			res, err := config.Load()
 			if err != nil {
 				return err
@@ -32,15 +54,26 @@ func listCmd() *cobra.Command {
 			out, err := workflow.ListBranches(res.Config, workflow.BranchListOptions{
 				RepoPath: repoPath,
 				Base:     base,
+				Scope:    scope,
+				Mine:     mine,
+				Stale:    stale,
+				Merged:   merged,
+				Prefix:   prefix,
+				Sort:     sortBy,
+				WithPRs:  prs,
 			})
 			if err != nil {
 				return err
 			}
 
-			if len(out.Branches) == 0 {
-				c, _ := cli.CommonFromCmd(cmd)
-				c.UI.Success("No branches found")
-				return nil
+			if jsonFlag {
+				plainUI := ui.New(ui.Options{
+					Out:     cmd.OutOrStdout(),
+					Color:   false,
+					Emoji:   false,
+					Verbose: false,
+				})
+				return cli.WriteJSON(plainUI, branchListPayload(out))
 			}
 
 			c, err := cli.CommonFromCmd(cmd)
@@ -48,19 +81,44 @@ func listCmd() *cobra.Command {
 				return err
 			}
 
+			for _, w := range out.Warnings {
+				c.UI.Warn("%s", w)
+			}
+			if len(out.Branches) == 0 {
+				c.UI.Success("No branches found")
+				return nil
+			}
+
 			c.UI.Header("Branches")
 			cli.PrintConfigSource(c.UI, c.ConfigResult.Path)
 			c.UI.Line("Base: %s", out.Base)
 			c.UI.Line("")
 
+			headers := []string{"CUR", "NAME", "AGE", "AHEAD", "BEHIND", "AUTHOR", "ISSUE", "DESCRIPTION", "LAST"}
+			if prs {
+				headers = append(headers, "PR")
+			}
+
 			t := ui.NewTable(cmd.OutOrStdout())
-			t.Header("CUR", "NAME", "AGE", "AHEAD", "BEHIND", "AUTHOR", "LAST")
+			t.Header(headers...)
 			for _, b := range out.Branches {
 				cur := ""
 				if b.Current {
 					cur = "*"
 				}
-				t.Row(cur, b.Name, b.AgeDays, b.Ahead, b.Behind, b.Author, b.LastCommitMsg)
+				name := b.Name
+				if b.Remote != "" {
+					name = b.Remote + "/" + b.Name
+				}
+				row := []any{cur, name, b.AgeDays, b.Ahead, b.Behind, b.Author, b.Issue, b.Description, b.LastCommitMsg}
+				if prs {
+					pr := ""
+					if b.PRNumber > 0 {
+						pr = fmt.Sprintf("#%d %s", b.PRNumber, b.PRState)
+					}
+					row = append(row, pr)
+				}
+				t.Row(row...)
 			}
 			t.Flush()
 
@@ -69,5 +127,14 @@ func listCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&base, "base", "", "Base branch to compare against")
+	cmd.Flags().BoolVarP(&remote, "remote", "r", false, "List remote-tracking branches instead of local ones")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "List local and remote-tracking branches")
+	cmd.Flags().BoolVar(&mine, "mine", false, "Only show branches whose last commit is by git user.name")
+	cmd.Flags().IntVar(&stale, "stale", 0, "Only show branches with no commits in the last N days")
+	cmd.Flags().BoolVar(&merged, "merged", false, "Only show branches merged into the base branch")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only show branches whose name starts with this prefix")
+	cmd.Flags().StringVar(&sortBy, "sort", "name", "Sort by name, age, ahead or behind")
+	cmd.Flags().BoolVar(&prs, "prs", false, "Show the pull request for each branch from the provider")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output machine readable JSON")
 	return cmd
 }
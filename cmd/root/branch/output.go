@@ -0,0 +1,47 @@
+package branch
+
+import "gitflow/internal/workflow"
+
+type branchOutput struct {
+	Name        string `json:"name"`
+	Remote      string `json:"remote,omitempty"`
+	Current     bool   `json:"current"`
+	Author      string `json:"author"`
+	AgeDays     int    `json:"age_days"`
+	Ahead       int    `json:"ahead"`
+	Behind      int    `json:"behind"`
+	Merged      bool   `json:"merged"`
+	Issue       string `json:"issue,omitempty"`
+	Description string `json:"description,omitempty"`
+	LastCommit  string `json:"last_commit"`
+	PRNumber    int    `json:"pr_number,omitempty"`
+	PRState     string `json:"pr_state,omitempty"`
+}
+
+type branchListOutput struct {
+	Base     string         `json:"base"`
+	Branches []branchOutput `json:"branches"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
+
+func branchListPayload(res *workflow.BranchListResult) branchListOutput {
+	payload := branchListOutput{Base: res.Base, Branches: []branchOutput{}, Warnings: res.Warnings}
+	for _, b := range res.Branches {
+		payload.Branches = append(payload.Branches, branchOutput{
+			Name:        b.Name,
+			Remote:      b.Remote,
+			Current:     b.Current,
+			Author:      b.Author,
+			AgeDays:     b.AgeDays,
+			Ahead:       b.Ahead,
+			Behind:      b.Behind,
+			Merged:      b.Merged,
+			Issue:       b.Issue,
+			Description: b.Description,
+			LastCommit:  b.LastCommitMsg,
+			PRNumber:    b.PRNumber,
+			PRState:     b.PRState,
+		})
+	}
+	return payload
+}
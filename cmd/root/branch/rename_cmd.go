@@ -0,0 +1,65 @@
+package branch
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gitflow/internal/cli"
+	"gitflow/internal/workflow"
+
+	"github.com/spf13/cobra"
+)
+
+func renameCmd() *cobra.Command {
+	var remote string
+
+	cmd := &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a branch locally, on the remote and in its pull request",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := cli.CommonFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			repoPath, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+
+			res, err := workflow.RenameBranch(c.ConfigResult.Config, workflow.BranchRenameOptions{
+				RepoPath: repoPath,
+				Old:      args[0],
+				New:      args[1],
+				Remote:   remote,
+			})
+			if err != nil {
+				return err
+			}
+
+			c.UI.Success("Renamed %s to %s", res.Old, res.New)
+			if res.RemoteRenamed {
+				if res.Retargeted {
+					c.UI.Line("Remote: renamed %s/%s through the provider, pull requests follow the new name", res.Remote, res.Old)
+				} else {
+					c.UI.Line("Remote: pushed %s/%s and deleted %s/%s", res.Remote, res.New, res.Remote, res.Old)
+				}
+				c.UI.Line("Upstream: %s", res.Upstream)
+			} else {
+				c.UI.Line("Remote: %s was not on %s, nothing pushed", res.Old, res.Remote)
+			}
+			if len(res.Children) > 0 {
+				c.UI.Line("Updated parent of: %s", strings.Join(res.Children, ", "))
+			}
+			for _, w := range res.Warnings {
+				c.UI.Warn("%s", w)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&remote, "remote", "origin", "Remote to rename the branch on")
+	return cmd
+}
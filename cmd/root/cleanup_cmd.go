@@ -5,10 +5,10 @@ import (
 	"os"
 	"strings"
 
-	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
 	"gitflow/internal/cli"
+	"gitflow/internal/ui"
 	"gitflow/internal/workflow"
 )
 
@@ -18,10 +18,16 @@ func cleanupCmd() *cobra.Command {
 	var age int
 	var remote bool
 	var remoteName string
+	var gone bool
+	var remoteBranches bool
+	var force bool
+	var interactive bool
+	var undo string
 
 	cmd := &cobra.Command{
-		Use:   "cleanup",
+		Use:   "cleanup [--undo [run-id]]",
 		Short: "Delete merged or stale branches safely",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := cli.CommonFromCmd(cmd)
 			if err != nil {
@@ -33,14 +39,39 @@ func cleanupCmd() *cobra.Command {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
 
-			out, err := workflow.Cleanup(c.ConfigResult.Config, workflow.CleanupOptions{
+			if cmd.Flags().Changed("undo") {
+				runID := strings.TrimSpace(undo)
+				if runID == "" && len(args) > 0 {
+					runID = args[0]
+				}
+				return runCleanupUndo(c, repoPath, runID)
+			}
+			if len(args) > 0 {
+				return fmt.Errorf("unexpected argument %q", args[0])
+			}
+
+			if gone && remoteBranches {
+				return fmt.Errorf("choose only one of --gone or --remote-branches")
+			}
+			mode := workflow.CleanupLocal
+			if gone {
+				mode = workflow.CleanupGone
+			}
+			if remoteBranches {
+				mode = workflow.CleanupRemote
+			}
+
+			opts := workflow.CleanupOptions{
 				RepoPath:     repoPath,
 				Remote:       remoteName,
+				Mode:         mode,
 				Yes:          yes,
 				All:          all,
 				AgeThreshold: age,
 				DeleteRemote: remote,
-			})
+				Force:        force,
+			}
+			out, err := workflow.Cleanup(c.ConfigResult.Config, opts)
 			if err != nil {
 				return err
 			}
@@ -50,95 +81,236 @@ func cleanupCmd() *cobra.Command {
 
 			c.UI.Line("Base branch: %s", out.BaseBranch)
 			c.UI.Line("Current branch: %s", out.Current)
+			for _, w := range out.Warnings {
+				c.UI.Warn("%s", w)
+			}
 
 			if len(out.Candidates) == 0 {
 				c.UI.Success("Nothing to cleanup")
 				return nil
 			}
 
-			if !yes && len(out.Deleted) == 0 {
-				choices := make([]huh.Option[string], 0, len(out.Candidates))
-				for _, b := range out.Candidates {
-					label := fmt.Sprintf("%s  reason %s  age %d  ahead %d  behind %d", b.Name, b.Reason, b.AgeDays, b.Ahead, b.Behind)
-					choices = append(choices, huh.NewOption(label, b.Name))
-				}
+			if mode == workflow.CleanupRemote && !interactive {
+				printRemoteCleanup(cmd, c, remoteName, out)
+				return nil
+			}
 
-				var selected []string
-				form := huh.NewForm(
-					huh.NewGroup(
-						huh.NewMultiSelect[string]().
-							Title("Select branches to delete").
-							Options(choices...).
-							Value(&selected),
-					),
-				)
-
-				if err := form.Run(); err != nil {
-					return err
-				}
+			if !yes {
+				return runCleanupPicker(c, opts, out)
+			}
 
-				if len(selected) == 0 {
-					c.UI.Warn("No branches selected")
-					return nil
-				}
+			printCleanupDeletions(c, out)
+			return nil
+		},
+	}
 
-				out2, err := workflow.Cleanup(c.ConfigResult.Config, workflow.CleanupOptions{
-					RepoPath:     repoPath,
-					Remote:       remoteName,
-					Yes:          true,
-					All:          all,
-					AgeThreshold: age,
-					DeleteRemote: remote,
-					Selected:     selected,
-				})
-				if err != nil {
-					return err
-				}
+	cmd.Flags().BoolVar(&yes, "yes", false, "Skip confirmation and delete all candidates")
+	cmd.Flags().BoolVar(&all, "all", false, "Include stale unmerged branches by age")
+	cmd.Flags().IntVar(&age, "age", 0, "Age threshold in days")
+	cmd.Flags().BoolVar(&remote, "remote", false, "Also delete remote branches")
+	cmd.Flags().StringVar(&remoteName, "remote-name", "origin", "Remote name")
+	cmd.Flags().BoolVar(&gone, "gone", false, "Find local branches whose upstream was deleted")
+	cmd.Flags().BoolVar(&remoteBranches, "remote-branches", false, "Find merged or stale branches on the remote; delete them with --yes")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Pick branches to delete (default for local cleanups without --yes)")
+	cmd.Flags().BoolVar(&force, "force", false, "Allow deleting branches that are not merged into the base branch")
+	cmd.Flags().StringVar(&undo, "undo", "", "Restore branches deleted by a cleanup run (latest when no id is given)")
+	cmd.Flags().Lookup("undo").NoOptDefVal = " "
 
-				c.UI.Line("Deleted: %d", len(out2.Deleted))
-				for _, b := range out2.Deleted {
-					c.UI.Line("  %s", b)
-				}
+	cmd.AddCommand(cleanupHistoryCmd())
 
-				if remote && len(out2.RemoteDeleted) > 0 {
-					c.UI.Line("Remote deleted: %d", len(out2.RemoteDeleted))
-					for _, b := range out2.RemoteDeleted {
-						c.UI.Line("  %s", b)
-					}
-				}
+	return cmd
+}
 
-				return nil
+func printRemoteCleanup(cmd *cobra.Command, c *cli.Common, remoteName string, out *workflow.CleanupResult) {
+	t := ui.NewTable(cmd.OutOrStdout())
+	t.Header("NAME", "REASON", "AUTHOR", "AGE", "AHEAD", "BEHIND")
+	for _, b := range out.Candidates {
+		t.Row(b.Name, b.Reason, b.Author, b.AgeDays, b.Ahead, b.Behind)
+	}
+	t.Flush()
+
+	if len(out.RemoteDeleted) == 0 {
+		c.UI.Line("")
+		c.UI.Warn("Nothing deleted; run with --yes to delete these branches from %s", remoteName)
+		return
+	}
+
+	printCleanupDeletions(c, out)
+}
+
+// runCleanupPicker lets the user choose candidates, confirms the local and
+// remote deletions and then runs the cleanup for the chosen branches only.
+// Unmerged branches are marked in the list and the summary, so confirming
+// them counts as forcing their deletion.
+func runCleanupPicker(c *cli.Common, opts workflow.CleanupOptions, out *workflow.CleanupResult) error {
+	choices := make([]ui.CleanupChoice, 0, len(out.Candidates))
+	byName := make(map[string]workflow.CandidateBranch, len(out.Candidates))
+	for _, b := range out.Candidates {
+		byName[b.Name] = b
+		choices = append(choices, ui.CleanupChoice{
+			Name:       b.Name,
+			Reason:     b.Reason,
+			LastCommit: b.LastCommit,
+			AgeDays:    b.AgeDays,
+			Ahead:      b.Ahead,
+			Behind:     b.Behind,
+			Unmerged:   b.Unmerged,
+		})
+	}
 
+	selected, err := ui.PromptCleanupSelection("Select branches to delete", choices)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		c.UI.Warn("No branches selected")
+		return nil
+	}
+
+	summary := ui.CleanupSummary{RemoteName: opts.Remote}
+	for _, name := range selected {
+		b := byName[name]
+		if out.Mode == workflow.CleanupRemote {
+			summary.Remote = append(summary.Remote, name)
+		} else {
+			summary.Local = append(summary.Local, name)
+			if opts.DeleteRemote && b.OnRemote {
+				summary.Remote = append(summary.Remote, name)
 			}
+		}
+		if b.Unmerged {
+			summary.Unmerged = append(summary.Unmerged, name)
+		}
+	}
 
-			if len(out.Deleted) == 0 {
-				c.UI.Success("Deleted: none")
-				return nil
+	ok, err := ui.ConfirmCleanup(summary)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		c.UI.Warn("Cleanup cancelled")
+		return nil
+	}
+
+	opts.Yes = true
+	opts.Selected = selected
+	opts.Force = opts.Force || len(summary.Unmerged) > 0
+
+	res, err := workflow.Cleanup(c.ConfigResult.Config, opts)
+	if err != nil {
+		return err
+	}
+	printCleanupDeletions(c, res)
+	return nil
+}
+
+func printCleanupDeletions(c *cli.Common, out *workflow.CleanupResult) {
+	if out.Mode != workflow.CleanupRemote {
+		if len(out.Deleted) == 0 {
+			c.UI.Success("Deleted: none")
+			return
+		}
+		c.UI.Line("Deleted: %d", len(out.Deleted))
+		for _, b := range out.Deleted {
+			c.UI.Line("  %s", b)
+		}
+	}
+
+	if len(out.RemoteDeleted) > 0 {
+		c.UI.Line("Remote deleted: %d", len(out.RemoteDeleted))
+		for _, b := range out.RemoteDeleted {
+			c.UI.Line("  %s", b)
+		}
+	}
+	printUndoHint(c, out.RunID)
+}
+
+func printUndoHint(c *cli.Common, runID string) {
+	if runID == "" {
+		return
+	}
+	c.UI.Line("Undo with: gitflow cleanup --undo %s", runID)
+}
+
+func runCleanupUndo(c *cli.Common, repoPath string, runID string) error {
+	out, err := workflow.CleanupUndo(workflow.CleanupUndoOptions{
+		RepoPath: repoPath,
+		RunID:    runID,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.UI.Header("Undo cleanup")
+	c.UI.Line("Run: %s (%s)", out.Run.ID, out.Run.At.Local().Format("2006-01-02 15:04"))
+
+	if len(out.Restored) > 0 {
+		c.UI.Line("Restored: %d", len(out.Restored))
+		for _, b := range out.Restored {
+			c.UI.Line("  %s", b)
+		}
+	}
+	if len(out.RemoteRestored) > 0 {
+		c.UI.Line("Remote restored: %d", len(out.RemoteRestored))
+		for _, b := range out.RemoteRestored {
+			c.UI.Line("  %s/%s", out.Run.Remote, b)
+		}
+	}
+	for _, s := range out.Skipped {
+		name := s.Name
+		if s.Remote {
+			name = out.Run.Remote + "/" + s.Name
+		}
+		c.UI.Warn("Skipped %s: %s", name, s.Reason)
+	}
+	if out.Failed {
+		c.UI.Line("Retry with: gitflow cleanup --undo %s", out.Run.ID)
+	}
+	if len(out.Skipped) == 0 {
+		c.UI.Success("All branches restored")
+	}
+	return nil
+}
+
+func cleanupHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history",
+		Short: "List past cleanup runs that can be undone",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := cli.CommonFromCmd(cmd)
+			if err != nil {
+				return err
 			}
 
-			c.UI.Line("Deleted: %d", len(out.Deleted))
-			for _, b := range out.Deleted {
-				c.UI.Line("  %s", b)
+			repoPath, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
 			}
 
-			if remote && len(out.RemoteDeleted) > 0 {
-				c.UI.Line("Remote deleted: %d", len(out.RemoteDeleted))
-				for _, b := range out.RemoteDeleted {
-					c.UI.Line("  %s", b)
-				}
+			runs, err := workflow.CleanupHistory(repoPath)
+			if err != nil {
+				return err
+			}
+
+			c.UI.Header("Cleanup history")
+			if len(runs) == 0 {
+				c.UI.Line("No cleanup runs recorded")
+				return nil
 			}
 
+			t := ui.NewTable(cmd.OutOrStdout())
+			t.Header("RUN", "WHEN", "MODE", "LOCAL", "REMOTE", "UNDONE")
+			for _, r := range runs {
+				undone := ""
+				if r.UndoneAt != nil {
+					undone = r.UndoneAt.Local().Format("2006-01-02 15:04")
+				}
+				t.Row(r.ID, r.At.Local().Format("2006-01-02 15:04"), r.Mode, len(r.Local), len(r.Remotes), undone)
+			}
+			t.Flush()
 			return nil
 		},
 	}
-
-	cmd.Flags().BoolVar(&yes, "yes", false, "Skip confirmation and delete all candidates")
-	cmd.Flags().BoolVar(&all, "all", false, "Include stale unmerged branches by age")
-	cmd.Flags().IntVar(&age, "age", 0, "Age threshold in days")
-	cmd.Flags().BoolVar(&remote, "remote", false, "Also delete remote branches")
-	cmd.Flags().StringVar(&remoteName, "remote-name", "origin", "Remote name")
-
-	return cmd
 }
 
 func contains(list []string, v string) bool {
@@ -23,6 +23,9 @@ func commitCmd() *cobra.Command {
 	var scope string
 	var breaking bool
 
+	var fixup bool
+	var target string
+
 	cmd := &cobra.Command{
 		Use:   "commit",
 		Short: "Create a commit using conventions and optional prompts",
@@ -37,6 +40,16 @@ func commitCmd() *cobra.Command {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
 
+			if len(args) > 0 {
+				return fmt.Errorf("unexpected argument %q; use --message for the commit summary or --target for the fixup commit", args[0])
+			}
+			if cmd.Flags().Changed("target") && !fixup {
+				return fmt.Errorf("--target needs --fixup")
+			}
+			if fixup {
+				return runFixup(cmd, common, repoPath, target, all)
+			}
+
 			useInteractive := interactive
 			if !useInteractive {
 				if strings.TrimSpace(msg) == "" && strings.TrimSpace(ctype) == "" && strings.TrimSpace(scope) == "" && strings.TrimSpace(body) == "" && !breaking && !all {
@@ -88,6 +101,9 @@ func commitCmd() *cobra.Command {
 			}
 			common.UI.Header("Commit created")
 			common.UI.Line(out.Message)
+			if out.Protected != "" {
+				common.UI.Warn("Committed directly on protected branch %s (matches %q)", out.Branch, out.Protected)
+			}
 			common.UI.Success("Done")
 
 			return nil
@@ -104,5 +120,56 @@ func commitCmd() *cobra.Command {
 	cmd.Flags().StringVar(&scope, "scope", "", "Conventional scope")
 	cmd.Flags().BoolVar(&breaking, "breaking", false, "Mark as breaking change")
 
+	cmd.Flags().BoolVar(&fixup, "fixup", false, "Create a fixup commit for a commit on the branch")
+	cmd.Flags().StringVar(&target, "target", "", "Commit to fix up with --fixup (hash or subject search)")
+
 	return cmd
 }
+
+func runFixup(cmd *cobra.Command, common *cli.Common, repoPath string, search string, all bool) error {
+	cfg := common.ConfigResult.Config
+
+	candidates, err := workflow.FixupCandidates(cfg, repoPath, search)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no commit on this branch matches %q", strings.TrimSpace(search))
+	}
+
+	target := candidates[0].Hash
+	if len(candidates) > 1 {
+		choices := make([]ui.CommitChoice, 0, len(candidates))
+		for _, c := range candidates {
+			choices = append(choices, ui.CommitChoice{Hash: c.Hash, Subject: c.Subject})
+		}
+		target, err = ui.PromptCommitTarget("Select commit to fix up", choices)
+		if err != nil {
+			return err
+		}
+	}
+
+	out, err := workflow.Fixup(cfg, workflow.FixupOptions{
+		RepoPath: repoPath,
+		All:      all,
+		Target:   target,
+	})
+	if err != nil {
+		return err
+	}
+
+	common.UI.Header("Fixup commit created")
+	common.UI.Line("Target: %s %s", shortHash(out.Target.Hash), out.Target.Subject)
+	if out.Protected != "" {
+		common.UI.Warn("Committed directly on protected branch %s (matches %q)", out.Branch, out.Protected)
+	}
+	common.UI.Success("Run gitflow tidy to squash fixups")
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
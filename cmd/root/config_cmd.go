@@ -9,5 +9,9 @@ func configCmd() *cobra.Command {
 	}
 	cmd.AddCommand(configShowCmd())
 	cmd.AddCommand(configValidateCmd())
+	cmd.AddCommand(configSchemaCmd())
+	cmd.AddCommand(configGetCmd())
+	cmd.AddCommand(configSetCmd())
+	cmd.AddCommand(configUnsetCmd())
 	return cmd
 }
@@ -0,0 +1,82 @@
+package root
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"gitflow/internal/cli"
+	"gitflow/internal/config"
+)
+
+func configGetCmd() *cobra.Command {
+	var scope string
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a config value by dotted key",
+		Long:  "Print a config value by dotted key, such as workflows.sync.strategy.\n\nWithout --scope the resolved value from all layers is printed; with --scope only the value set in that file.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			if cmd.Flags().Changed("scope") {
+				file, err := openScopeFile(scope)
+				if err != nil {
+					return err
+				}
+				value, ok, err := file.Get(key)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("%s is not set in %s", key, file.Path)
+				}
+				cmd.Println(value)
+				return nil
+			}
+
+			c, err := cli.CommonFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+			value, err := config.Lookup(c.ConfigResult.Config, key)
+			if err != nil {
+				return err
+			}
+			if s, ok := value.(string); ok {
+				cmd.Println(s)
+				return nil
+			}
+			out, err := yaml.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to render yaml: %w", err)
+			}
+			cmd.Println(strings.TrimSpace(string(out)))
+			return nil
+		},
+	}
+
+	addScopeFlag(cmd, &scope)
+	return cmd
+}
+
+func addScopeFlag(cmd *cobra.Command, scope *string) {
+	cmd.Flags().StringVar(scope, "scope", config.LayerRepo, "Config file to use: repo, user or local")
+}
+
+// openScopeFile opens the config file for a --scope value from the current
+// directory.
+func openScopeFile(scope string) (*config.File, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	path, err := config.ScopePath(scope, wd)
+	if err != nil {
+		return nil, err
+	}
+	return config.OpenFile(path)
+}
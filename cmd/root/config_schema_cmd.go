@@ -0,0 +1,26 @@
+package root
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gitflow/internal/config"
+)
+
+func configSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for .gitflow.yml",
+		Long:  "Print the JSON Schema for .gitflow.yml.\n\nPoint your editor's YAML language server at it for completion and validation, for example with\n# yaml-language-server: $schema=./gitflow.schema.json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := json.MarshalIndent(config.Schema(), "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to render schema: %w", err)
+			}
+			cmd.Println(string(out))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package root
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func configSetCmd() *cobra.Command {
+	var scope string
+
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a config value by dotted key",
+		Long:  "Set a config value by dotted key, keeping the file's comments and key order.\n\nLists are comma separated, for example\ngitflow config set branches.protected main,release/*",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := openScopeFile(scope)
+			if err != nil {
+				return err
+			}
+			if err := file.Set(args[0], args[1]); err != nil {
+				return err
+			}
+			if err := file.Save(); err != nil {
+				return err
+			}
+
+			cmd.Printf("Set %s in %s\n", args[0], file.Path)
+			return nil
+		},
+	}
+
+	addScopeFlag(cmd, &scope)
+	return cmd
+}
@@ -10,7 +10,9 @@ import (
 )
 
 func configShowCmd() *cobra.Command {
-	return &cobra.Command{
+	var origin bool
+
+	cmd := &cobra.Command{
 		Use:   "show",
 		Short: "Print the resolved configuration",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -18,14 +20,24 @@ func configShowCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			res := c.ConfigResult
 
-			res, err := config.Load()
-			if err != nil {
-				return err
+			cli.PrintConfigSource(c.UI, res.Path)
+			for _, v := range res.Env {
+				c.UI.Line("Environment: %s=%s (%s)", v.Name, v.Value, v.Key)
+			}
+			if origin {
+				for _, layer := range res.Layers {
+					c.UI.Line("Layer %s: %s", layer.Name, layer.Path)
+				}
 			}
 
-			cli.PrintConfigSource(c.UI, c.ConfigResult.Path)
-			out, err := yaml.Marshal(res.Config)
+			var out []byte
+			if origin {
+				out, err = config.MarshalWithOrigins(res.Config, res.Origins)
+			} else {
+				out, err = yaml.Marshal(res.Config)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to render yaml: %w", err)
 			}
@@ -34,4 +46,7 @@ func configShowCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&origin, "origin", false, "Show which layer set each value")
+	return cmd
 }
@@ -0,0 +1,38 @@
+package root
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func configUnsetCmd() *cobra.Command {
+	var scope string
+
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a config value so lower layers or defaults apply",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := openScopeFile(scope)
+			if err != nil {
+				return err
+			}
+			removed, err := file.Unset(args[0])
+			if err != nil {
+				return err
+			}
+			if !removed {
+				cmd.Printf("%s is not set in %s\n", args[0], file.Path)
+				return nil
+			}
+			if err := file.Save(); err != nil {
+				return err
+			}
+
+			cmd.Printf("Unset %s in %s\n", args[0], file.Path)
+			return nil
+		},
+	}
+
+	addScopeFlag(cmd, &scope)
+	return cmd
+}
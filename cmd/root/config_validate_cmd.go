@@ -16,7 +16,7 @@ func configValidateCmd() *cobra.Command {
 				return err
 			}
 
-			if err := config.ValidateStrict(res.Config); err != nil {
+			if err := config.ValidateResult(res); err != nil {
 				cmd.Println("Config invalid")
 				cmd.Println(err.Error())
 				return nil
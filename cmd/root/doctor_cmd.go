@@ -2,6 +2,7 @@ package root
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"gitflow/internal/cli"
@@ -12,7 +13,11 @@ import (
 )
 
 func doctorCmd() *cobra.Command {
-	return &cobra.Command{
+	var fix bool
+	var yes bool
+	var online bool
+
+	cmd := &cobra.Command{
 		Use:   "doctor",
 		Short: "Check repository health",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -32,25 +37,131 @@ func doctorCmd() *cobra.Command {
 			}
 
 			c.UI.Header("Doctor report")
+			printDoctorReport(c, cmd.OutOrStdout(), out)
 
-			t := ui.NewTable(cmd.OutOrStdout())
-			t.Header("STATUS", "CHECK", "MESSAGE")
+			if fix {
+				after, err := applyDoctorFixes(c, cmd.OutOrStdout(), repoPath, out, yes)
+				if err != nil {
+					return err
+				}
+				if after != nil {
+					out = after
+				}
+			}
 
-			hasErrors := false
-			for _, check := range out.Checks {
-				if check.Level == workflow.DoctorError {
-					hasErrors = true
+			failed := hasDoctorErrors(out)
+			if online {
+				remote, err := workflow.DoctorOnline(c.ConfigResult.Config)
+				if err != nil {
+					return err
 				}
-				status := c.UI.StatusLabel(check.Level)
-				t.Row(status, check.Name, check.Message)
+				c.UI.Header("Provider checks")
+				printDoctorReport(c, cmd.OutOrStdout(), remote)
+				failed = failed || hasDoctorErrors(remote)
 			}
-			t.Flush()
 
-			if hasErrors {
+			if failed {
 				return fmt.Errorf("doctor found errors")
 			}
 
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Apply safe fixes and re-run the checks")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Apply fixes without asking")
+	cmd.Flags().BoolVar(&online, "online", false, "Also check provider auth, token permissions and rate limits")
+	return cmd
+}
+
+// printDoctorReport prints the checks and the suggested fixes.
+func printDoctorReport(c *cli.Common, w io.Writer, out *workflow.DoctorResult) {
+	t := ui.NewTable(w)
+	t.Header("STATUS", "CHECK", "MESSAGE")
+	for _, check := range out.Checks {
+		t.Row(c.UI.StatusLabel(check.Level), check.Name, check.Message)
+	}
+	t.Flush()
+
+	var fixes []workflow.DoctorCheck
+	for _, check := range out.Checks {
+		if check.Level != workflow.DoctorOK && check.Fix != "" {
+			fixes = append(fixes, check)
+		}
+	}
+	if len(fixes) > 0 {
+		c.UI.Header("Suggested fixes")
+		for _, check := range fixes {
+			c.UI.Line("%s: %s", check.Name, check.Fix)
+		}
+	}
+}
+
+func hasDoctorErrors(out *workflow.DoctorResult) bool {
+	for _, check := range out.Checks {
+		if check.Level == workflow.DoctorError {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDoctorFixes runs the fix action of each failing check, asking first
+// unless yes is set, then re-runs doctor and prints the checks that changed.
+// It returns nil when there was nothing to apply.
+func applyDoctorFixes(c *cli.Common, w io.Writer, repoPath string, before *workflow.DoctorResult, yes bool) (*workflow.DoctorResult, error) {
+	var fixable []workflow.DoctorCheck
+	for _, check := range before.Checks {
+		if check.Level != workflow.DoctorOK && check.Action != nil {
+			fixable = append(fixable, check)
+		}
+	}
+
+	c.UI.Header("Applying fixes")
+	if len(fixable) == 0 {
+		c.UI.Line("Nothing doctor can fix automatically")
+		return nil, nil
+	}
+
+	applied := 0
+	for _, check := range fixable {
+		if !yes {
+			ok, err := ui.ConfirmDoctorFix(check.Name, check.Action.Description)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				c.UI.Line("Skipped %s", check.Name)
+				continue
+			}
+		}
+		if err := check.Action.Apply(); err != nil {
+			c.UI.Warn("%s: %v", check.Name, err)
+			continue
+		}
+		applied++
+		c.UI.Success("%s", check.Action.Description)
+	}
+	if applied == 0 {
+		return nil, nil
+	}
+
+	after, err := workflow.Doctor(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UI.Header("Changes")
+	changes := workflow.DiffDoctor(before, after)
+	if len(changes) == 0 {
+		c.UI.Line("No check changed")
+		return after, nil
+	}
+	t := ui.NewTable(w)
+	t.Header("CHECK", "BEFORE", "AFTER")
+	for _, change := range changes {
+		t.Row(change.Name, c.UI.StatusLabel(change.Before), c.UI.StatusLabel(change.After))
+	}
+	t.Flush()
+	return after, nil
 }
@@ -3,41 +3,116 @@ package root
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
-	"gitflow/internal/config"
+	"gitflow/internal/ui"
 	"gitflow/internal/workflow"
 )
 
 func initCmd() *cobra.Command {
 	var force bool
+	var yes bool
 
 	cmd := &cobra.Command{
 		Use:   "init",
-		Short: "Create a starter .gitflow.yml in the current repo",
+		Short: "Create a .gitflow.yml for the current repo",
+		Long: `Create a .gitflow.yml for the current repo.
+
+The provider, owner and repository are read from the origin remote, the
+default branch from origin/HEAD, and develop is used as the base branch when
+it exists. Conventional commits are proposed when most recent commits follow
+them, with the scopes they use. Each setting can be changed before the file
+is written; --yes writes the detected settings without asking.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			repoPath, err := os.Getwd()
 			if err != nil {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
 
-			cfg := config.Default()
-
-			out, err := workflow.Init(cfg, workflow.InitOptions{
+			opts := workflow.InitOptions{
 				RepoPath: repoPath,
 				Force:    force,
-			})
+			}
+			if _, err := workflow.InitPath(opts); err != nil {
+				return err
+			}
+
+			defaults, err := workflow.DetectInit(repoPath)
+			if err != nil {
+				cmd.PrintErrf("Could not inspect the repository, using defaults: %v\n", err)
+				defaults = &workflow.InitDefaults{}
+			}
+
+			if !yes {
+				in, err := ui.PromptInit(initPromptDefaults(defaults))
+				if err != nil {
+					return err
+				}
+				applyInitAnswers(defaults, in)
+			}
+
+			out, err := workflow.Init(defaults.Config(), opts)
 			if err != nil {
 				return err
 			}
 
 			cmd.Printf("Wrote %s\n", out.Path)
-			cmd.Println("Edit provider settings to enable PR features")
+			if defaults.Provider == "" {
+				cmd.Println("Edit provider settings to enable PR features")
+			} else {
+				cfg := defaults.Config()
+				cmd.Printf("Export %s with a %s token to enable PR features\n", cfg.Provider.TokenEnv, cfg.Provider.Type)
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing file")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Write the detected settings without asking")
 	return cmd
 }
+
+func initPromptDefaults(d *workflow.InitDefaults) ui.InitPromptInput {
+	cfg := d.Config()
+
+	history := "No commits to learn from yet"
+	if d.Commits > 0 {
+		history = fmt.Sprintf("%d of the last %d commits follow the convention", d.ConventionalCommits, d.Commits)
+	}
+
+	return ui.InitPromptInput{
+		Provider:     d.Provider,
+		Owner:        d.Owner,
+		Repo:         d.Repo,
+		MainBranch:   cfg.Branches.MainBranch,
+		BaseBranch:   cfg.Workflows.Start.BaseBranch,
+		Conventional: d.Conventional,
+		Scopes:       strings.Join(d.Scopes, ","),
+		History:      history,
+	}
+}
+
+// applyInitAnswers copies the wizard answers onto d. A base branch other
+// than the default branch is treated as the develop branch.
+func applyInitAnswers(d *workflow.InitDefaults, in ui.InitPromptInput) {
+	if in.Provider != d.Provider {
+		d.BaseURL = ""
+	}
+	d.Provider = in.Provider
+	d.Owner = in.Owner
+	d.Repo = in.Repo
+	d.DefaultBranch = in.MainBranch
+	d.Develop = ""
+	if in.BaseBranch != "" && in.BaseBranch != in.MainBranch {
+		d.Develop = in.BaseBranch
+	}
+	d.Conventional = in.Conventional
+	d.Scopes = nil
+	for _, scope := range strings.Split(in.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			d.Scopes = append(d.Scopes, scope)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package root
+
+import "gitflow/internal/workflow"
+
+type statusPROutput struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	Draft  bool   `json:"draft"`
+	URL    string `json:"url"`
+	Checks string `json:"checks,omitempty"`
+}
+
+type statusOutput struct {
+	Branch       string          `json:"branch"`
+	Detached     bool            `json:"detached"`
+	Head         string          `json:"head"`
+	Dirty        bool            `json:"dirty"`
+	Upstream     string          `json:"upstream,omitempty"`
+	UpstreamGone bool            `json:"upstream_gone"`
+	Ahead        int             `json:"ahead"`
+	Behind       int             `json:"behind"`
+	Base         string          `json:"base,omitempty"`
+	BaseAhead    int             `json:"base_ahead"`
+	BaseBehind   int             `json:"base_behind"`
+	Staged       int             `json:"staged"`
+	Unstaged     int             `json:"unstaged"`
+	Untracked    int             `json:"untracked"`
+	Conflicted   int             `json:"conflicted"`
+	Stashes      int             `json:"stashes"`
+	Operation    string          `json:"operation,omitempty"`
+	PR           *statusPROutput `json:"pr,omitempty"`
+	LatestTag    string          `json:"latest_tag,omitempty"`
+	Unreleased   int             `json:"unreleased"`
+	Warnings     []string        `json:"warnings,omitempty"`
+}
+
+func statusPayload(s *workflow.Status) statusOutput {
+	out := statusOutput{
+		Branch:       s.Branch,
+		Detached:     s.Detached,
+		Head:         s.Head,
+		Dirty:        s.Dirty,
+		Upstream:     s.Upstream,
+		UpstreamGone: s.UpstreamGone,
+		Ahead:        s.Ahead,
+		Behind:       s.Behind,
+		Base:         s.Base,
+		BaseAhead:    s.BaseAhead,
+		BaseBehind:   s.BaseBehind,
+		Staged:       s.Staged,
+		Unstaged:     s.Unstaged,
+		Untracked:    s.Untracked,
+		Conflicted:   s.Conflicted,
+		Stashes:      s.Stashes,
+		Operation:    s.Operation,
+		LatestTag:    s.LatestTag,
+		Unreleased:   s.Unreleased,
+		Warnings:     s.Warnings,
+	}
+	if s.PR != nil {
+		out.PR = &statusPROutput{
+			Number: s.PR.Number,
+			State:  s.PR.State,
+			Draft:  s.PR.Draft,
+			URL:    s.PR.URL,
+			Checks: s.Checks,
+		}
+	}
+	return out
+}
@@ -7,7 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"gitflow/internal/config"
+	"gitflow/internal/cli"
 	"gitflow/internal/ui"
 	"gitflow/internal/workflow"
 )
@@ -23,6 +23,7 @@ func createCmd() *cobra.Command {
 	var remote string
 	var interactive bool
 	var open bool
+	var autostash bool
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -33,7 +34,7 @@ func createCmd() *cobra.Command {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
 
-			res, err := config.Load()
+			c, err := cli.CommonFromCmd(cmd)
 			if err != nil {
 				return err
 			}
@@ -52,12 +53,19 @@ func createCmd() *cobra.Command {
 			}
 
 			if useInteractive {
+				if strings.TrimSpace(title) == "" && strings.TrimSpace(body) == "" {
+					if d, err := workflow.DefaultPRContent(c.ConfigResult.Config, repoPath); err == nil {
+						title = d.Title
+						body = d.Description
+					}
+				}
+
 				def := ui.PRPromptInput{
 					Title:       title,
 					Description: body,
-					Draft:       res.Config.Workflows.PR.Draft,
-					Reviewers:   strings.Join(res.Config.Workflows.PR.DefaultReviewers, ","),
-					Labels:      strings.Join(res.Config.Workflows.PR.Labels, ","),
+					Draft:       c.ConfigResult.Config.Workflows.PR.Draft,
+					Reviewers:   strings.Join(c.ConfigResult.Config.Workflows.PR.DefaultReviewers, ","),
+					Labels:      strings.Join(c.ConfigResult.Config.Workflows.PR.Labels, ","),
 					BaseBranch:  base,
 					OpenBrowser: open,
 				}
@@ -88,7 +96,13 @@ func createCmd() *cobra.Command {
 				labels = in.Labels
 			}
 
-			out, err := workflow.CreatePR(res.Config, workflow.PRCreateOptions{
+			var autostashOverride *bool
+			if cmd.Flags().Changed("autostash") {
+				v := autostash
+				autostashOverride = &v
+			}
+
+			out, err := workflow.CreatePR(c.ConfigResult.Config, workflow.PRCreateOptions{
 				RepoPath:    repoPath,
 				Remote:      remote,
 				Title:       title,
@@ -97,6 +111,8 @@ func createCmd() *cobra.Command {
 				Draft:       draftPtr,
 				Reviewers:   splitCSV(reviewers),
 				Labels:      splitCSV(labels),
+
+				AutostashOverride: autostashOverride,
 			})
 			if err != nil {
 				return err
@@ -106,6 +122,7 @@ func createCmd() *cobra.Command {
 			cmd.Printf("PR created: #%d\n", pr.Number)
 			cmd.Printf("Title: %s\n", pr.Title)
 			cmd.Printf("URL: %s\n", pr.URL)
+			cli.PrintAutostash(c.UI, out.Autostash)
 
 			if open {
 				_ = ui.OpenURL(pr.URL)
@@ -124,6 +141,7 @@ func createCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&interactive, "interactive", false, "Prompt for missing fields")
 	cmd.Flags().BoolVar(&open, "open", false, "Open PR in browser after creation")
 	cmd.Flags().BoolVar(&draft, "draft", false, "Create as draft PR")
+	cmd.Flags().BoolVar(&autostash, "autostash", false, "Stash local changes before creating the PR and restore them after")
 
 	cmd.Flags().Lookup("draft").NoOptDefVal = "true"
 	cmd.PreRun = func(cmd *cobra.Command, args []string) {
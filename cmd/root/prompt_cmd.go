@@ -0,0 +1,109 @@
+package root
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gitflow/internal/workflow"
+
+	"github.com/spf13/cobra"
+)
+
+// promptSnippets define a __gitflow_prompt function for each shell. Add it to
+// the prompt after evaluating the output of gitflow prompt init <shell>.
+var promptSnippets = map[string]string{
+	"bash": `# gitflow prompt: add $(__gitflow_prompt) to PS1, for example
+#   PS1='\w$(__gitflow_prompt)\$ '
+__gitflow_prompt() {
+  local s
+  s="$(command gitflow prompt 2>/dev/null)"
+  [ -n "$s" ] && printf ' (%s)' "$s"
+}
+`,
+	"zsh": `# gitflow prompt: add $(__gitflow_prompt) to PROMPT or RPROMPT, for example
+#   RPROMPT='$(__gitflow_prompt)'
+setopt prompt_subst
+__gitflow_prompt() {
+  local s
+  s="$(command gitflow prompt 2>/dev/null)"
+  [[ -n "$s" ]] && print -rn -- " (${s//\%/%%})"
+}
+`,
+	"fish": `# gitflow prompt: call __gitflow_prompt from fish_prompt or fish_right_prompt, for example
+#   function fish_right_prompt; __gitflow_prompt; end
+function __gitflow_prompt
+    set -l s (command gitflow prompt 2>/dev/null)
+    if test -n "$s"
+        printf ' (%s)' "$s"
+    end
+end
+`,
+}
+
+func promptCmd() *cobra.Command {
+	var format string
+	var budget time.Duration
+	var maxAge time.Duration
+	var noCache bool
+
+	cmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print a compact status line for shell prompts",
+		Long: "Print a compact status line for shell prompts.\n\n" +
+			"The format is a Go template with .Branch, .Dirty, .Ahead, .Behind, .Staged,\n" +
+			".Unstaged, .Untracked, .Operation and .PR. The default is:\n\n  " +
+			workflow.DefaultPromptFormat + "\n\n" +
+			"Results are cached in .git/gitflow/cache. When the time budget runs out the\n" +
+			"last cached line is printed instead. Run gitflow prompt init <shell> for a snippet.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repoPath, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+
+			if format == "" {
+				format = os.Getenv("GITFLOW_PROMPT_FORMAT")
+			}
+			line, err := workflow.Prompt(workflow.PromptOptions{
+				RepoPath: repoPath,
+				Format:   format,
+				Budget:   budget,
+				MaxAge:   maxAge,
+				NoCache:  noCache,
+			})
+			if err != nil {
+				return err
+			}
+			if line != "" {
+				fmt.Fprintln(cmd.OutOrStdout(), line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "Prompt template (default from GITFLOW_PROMPT_FORMAT or the built-in format)")
+	cmd.Flags().DurationVar(&budget, "budget", workflow.DefaultPromptBudget, "Maximum time to spend before falling back to the cache")
+	cmd.Flags().DurationVar(&maxAge, "max-age", workflow.DefaultPromptMaxAge, "Reuse cached status for this long while the index and HEAD are unchanged")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Ignore and do not write the prompt cache")
+	cmd.AddCommand(promptInitCmd())
+	return cmd
+}
+
+func promptInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       "init <bash|zsh|fish>",
+		Short:     "Print the shell snippet that defines __gitflow_prompt",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snippet, ok := promptSnippets[args[0]]
+			if !ok {
+				return fmt.Errorf("unsupported shell %q, expected bash, zsh or fish", args[0])
+			}
+			fmt.Fprint(cmd.OutOrStdout(), snippet)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package root
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPromptInitPrintsSnippets(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		var buf bytes.Buffer
+		cmd := promptCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs([]string{"init", shell})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("prompt init %s: %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), "__gitflow_prompt") || !strings.Contains(buf.String(), "gitflow prompt") {
+			t.Fatalf("unexpected %s snippet:\n%s", shell, buf.String())
+		}
+	}
+
+	cmd := promptCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"init", "tcsh"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected unsupported shell error")
+	}
+}
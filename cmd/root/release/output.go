@@ -1,11 +1,11 @@
 package release
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
+	"gitflow/internal/cli"
 	"gitflow/internal/ui"
 	"gitflow/internal/workflow"
 )
@@ -56,7 +56,7 @@ func outputReleasePreview(u *ui.UI, out io.Writer, format string, result *workfl
 			CommitCount:    result.CommitCount,
 			Changelog:      result.Changelog,
 		}
-		return writeJSON(u, payload)
+		return cli.WriteJSON(u, payload)
 	case outputEnv:
 		changelog := escapeEnvValue(result.Changelog)
 		lines := []string{
@@ -88,7 +88,7 @@ func outputReleasePreview(u *ui.UI, out io.Writer, format string, result *workfl
 func outputReleaseVersion(outWriter *ui.UI, format string, version string) error {
 	switch format {
 	case outputJSON:
-		return writeJSON(outWriter, versionOutput{Version: version})
+		return cli.WriteJSON(outWriter, versionOutput{Version: version})
 	case outputEnv:
 		outWriter.Line("GITFLOW_RELEASE_VERSION=%s", version)
 		return nil
@@ -107,7 +107,7 @@ func outputReleasePublish(u *ui.UI, out io.Writer, format string, result *workfl
 			URL:      publishResult.URL,
 			DryRun:   publishResult.DryRun,
 		}
-		return writeJSON(u, payload)
+		return cli.WriteJSON(u, payload)
 	case outputEnv:
 		lines := []string{
 			fmt.Sprintf("GITFLOW_RELEASE_PROVIDER=%s", publishResult.Provider),
@@ -134,15 +134,6 @@ func outputReleasePublish(u *ui.UI, out io.Writer, format string, result *workfl
 	}
 }
 
-func writeJSON(u *ui.UI, payload any) error {
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
-	u.Line("%s", string(data))
-	return nil
-}
-
 func escapeEnvValue(value string) string {
 	return strings.ReplaceAll(value, "\n", "\\n")
 }
@@ -42,14 +42,17 @@ func Execute() {
 		if cmd.Flags().Changed("no-color") {
 			color := !flagNoColor
 			overrides.Color = &color
+			overrides.ColorFlag = cmd.Flags().Lookup("no-color").Name
 		}
 		if cmd.Flags().Changed("emoji") {
 			emoji := flagEmoji
 			overrides.Emoji = &emoji
+			overrides.EmojiFlag = cmd.Flags().Lookup("emoji").Name
 		}
 		if cmd.Flags().Changed("verbose") {
 			verbose := flagVerbose
 			overrides.Verbose = &verbose
+			overrides.VerboseFlag = cmd.Flags().Lookup("verbose").Name
 		}
 		cli.SetUIOverrides(overrides)
 		return nil
@@ -57,12 +60,14 @@ func Execute() {
 
 	rootCmd.AddCommand(VersionCmd())
 	rootCmd.AddCommand(statusCmd())
+	rootCmd.AddCommand(promptCmd())
 	rootCmd.AddCommand(doctorCmd())
 	rootCmd.AddCommand(configCmd())
 	rootCmd.AddCommand(startCmd())
 	rootCmd.AddCommand(syncCmd())
 	rootCmd.AddCommand(cleanupCmd())
 	rootCmd.AddCommand(commitCmd())
+	rootCmd.AddCommand(tidyCmd())
 	rootCmd.AddCommand(initCmd())
 	rootCmd.AddCommand(provider.Cmd())
 	rootCmd.AddCommand(pr.Cmd())
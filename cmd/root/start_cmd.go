@@ -15,6 +15,10 @@ func startCmd() *cobra.Command {
 	var bugfix bool
 	var hotfix bool
 	var remote string
+	var autostash bool
+	var branchType string
+	var issue string
+	var description string
 
 	cmd := &cobra.Command{
 		Use:   "start <name>",
@@ -35,6 +39,10 @@ func startCmd() *cobra.Command {
 				return err
 			}
 
+			if branchType != "" && (bugfix || hotfix) {
+				return fmt.Errorf("choose only one of --type, --bugfix or --hotfix")
+			}
+
 			kind := "feature"
 			if bugfix {
 				kind = "bugfix"
@@ -42,6 +50,9 @@ func startCmd() *cobra.Command {
 			if hotfix {
 				kind = "hotfix"
 			}
+			if branchType != "" {
+				kind = branchType
+			}
 
 			repoPath, err := os.Getwd()
 			if err != nil {
@@ -53,12 +64,21 @@ func startCmd() *cobra.Command {
 				return err
 			}
 
+			var autostashOverride *bool
+			if cmd.Flags().Changed("autostash") {
+				v := autostash
+				autostashOverride = &v
+			}
+
 			name := strings.Join(args, " ")
 			out, err := workflow.Start(res.Config, workflow.StartOptions{
-				Kind:     kind,
-				RepoPath: repoPath,
-				Remote:   remote,
-				Name:     name,
+				Kind:              kind,
+				RepoPath:          repoPath,
+				Remote:            remote,
+				Name:              name,
+				Issue:             issue,
+				Description:       description,
+				AutostashOverride: autostashOverride,
 			})
 
 			if err != nil {
@@ -75,6 +95,10 @@ func startCmd() *cobra.Command {
 			} else {
 				c.UI.Warn("Remote: not pushed")
 			}
+			for _, w := range out.Warnings {
+				c.UI.Warn("%s", w)
+			}
+			cli.PrintAutostash(c.UI, out.Autostash)
 
 			return nil
 		},
@@ -82,6 +106,10 @@ func startCmd() *cobra.Command {
 
 	cmd.Flags().BoolVar(&bugfix, "bugfix", false, "Use bugfix prefix")
 	cmd.Flags().BoolVar(&hotfix, "hotfix", false, "Use hotfix prefix")
+	cmd.Flags().StringVar(&branchType, "type", "", "Branch type from branches.types (or feature, bugfix, hotfix)")
+	cmd.Flags().StringVar(&issue, "issue", "", "Issue key or number for branch templates using {{.Issue}}")
+	cmd.Flags().StringVar(&description, "description", "", "Branch description stored in its metadata (defaults to the name)")
 	cmd.Flags().StringVar(&remote, "remote", "origin", "Remote name")
+	cmd.Flags().BoolVar(&autostash, "autostash", false, "Stash local changes before starting and restore them after")
 	return cmd
 }
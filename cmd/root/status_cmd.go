@@ -11,10 +11,18 @@ import (
 )
 
 func statusCmd() *cobra.Command {
-	return &cobra.Command{
+	var base string
+	var jsonFlag bool
+	var short bool
+
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show repository status summary",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if jsonFlag && short {
+				return fmt.Errorf("choose only one of --json or --short")
+			}
+
 			c, err := cli.CommonFromCmd(cmd)
 			if err != nil {
 				return err
@@ -25,24 +33,99 @@ func statusCmd() *cobra.Command {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
 
-			s, err := workflow.GetStatus(repoPath)
+			// The one-line form is meant for prompts, so it never waits on the provider.
+			s, err := workflow.GetStatus(c.ConfigResult.Config, workflow.StatusOptions{
+				RepoPath: repoPath,
+				Base:     base,
+				WithPR:   !short,
+				Short:    short,
+			})
 			if err != nil {
 				return err
 			}
 
-			c.UI.Header("Repository status")
-			workingTree := "clean"
-			if s.Dirty {
-				workingTree = "dirty"
+			plainUI := ui.New(ui.Options{
+				Out:     cmd.OutOrStdout(),
+				Color:   false,
+				Emoji:   false,
+				Verbose: false,
+			})
+			if short {
+				plainUI.Line("%s", s.Short())
+				return nil
+			}
+			if jsonFlag {
+				return cli.WriteJSON(plainUI, statusPayload(s))
 			}
+
+			c.UI.Header("Repository status")
 			t := ui.NewTable(cmd.OutOrStdout())
 			t.Header("KEY", "VALUE")
-			t.KeyValue("Branch", s.Branch)
-			t.KeyValue("Working tree", workingTree)
+			t.KeyValue("Branch", statusBranch(s))
+			t.KeyValue("Upstream", statusUpstream(s))
+			if s.Base != "" && s.Base != s.Branch {
+				t.KeyValue("Base", fmt.Sprintf("%s (ahead %d, behind %d)", s.Base, s.BaseAhead, s.BaseBehind))
+			}
+			t.KeyValue("Working tree", statusWorkingTree(s))
+			if s.Stashes > 0 {
+				t.KeyValue("Stashes", s.Stashes)
+			}
+			if s.Operation != "" {
+				t.KeyValue("In progress", s.Operation)
+			}
+			if s.PR != nil {
+				pr := fmt.Sprintf("#%d %s", s.PR.Number, s.PR.URL)
+				if s.Checks != "" {
+					pr += ", checks " + s.Checks
+				}
+				t.KeyValue("Pull request", pr)
+			}
+			if s.LatestTag != "" {
+				t.KeyValue("Latest release", fmt.Sprintf("%s (%d unreleased commit(s))", s.LatestTag, s.Unreleased))
+			} else if s.Head != "" {
+				t.KeyValue("Latest release", fmt.Sprintf("none (%d unreleased commit(s))", s.Unreleased))
+			}
 			t.KeyValue("Config source", cli.ConfigSource(c.ConfigResult.Path))
 			t.Flush()
 
+			for _, w := range s.Warnings {
+				c.UI.Warn("%s", w)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&base, "base", "", "Base branch to compare against")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output machine readable JSON")
+	cmd.Flags().BoolVar(&short, "short", false, "Print a one-line summary for shell prompts")
+	return cmd
+}
+
+func statusBranch(s *workflow.Status) string {
+	if s.Detached {
+		return fmt.Sprintf("detached at %.7s", s.Head)
+	}
+	return s.Branch
+}
+
+func statusUpstream(s *workflow.Status) string {
+	switch {
+	case s.Upstream == "":
+		return "none"
+	case s.UpstreamGone:
+		return s.Upstream + " (gone)"
+	default:
+		return fmt.Sprintf("%s (ahead %d, behind %d)", s.Upstream, s.Ahead, s.Behind)
+	}
+}
+
+func statusWorkingTree(s *workflow.Status) string {
+	if !s.Dirty {
+		return "clean"
+	}
+	tree := fmt.Sprintf("%d staged, %d unstaged, %d untracked", s.Staged, s.Unstaged, s.Untracked)
+	if s.Conflicted > 0 {
+		tree += fmt.Sprintf(", %d conflicted", s.Conflicted)
+	}
+	return tree
 }
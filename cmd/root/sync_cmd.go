@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"gitflow/internal/cli"
 	"gitflow/internal/config"
+	"gitflow/internal/ui"
 	"gitflow/internal/workflow"
 	"os"
 
@@ -16,6 +17,10 @@ func syncCmd() *cobra.Command {
 	var rebase bool
 	var noPush bool
 	var force bool
+	var cont bool
+	var abort bool
+	var autostash bool
+	var all bool
 
 	cmd := &cobra.Command{
 		Use:   "sync",
@@ -24,6 +29,12 @@ func syncCmd() *cobra.Command {
 			if merge && rebase {
 				return fmt.Errorf("choose only one of merge or rebase")
 			}
+			if cont && abort {
+				return fmt.Errorf("choose only one of continue or abort")
+			}
+			if all && (cont || abort) {
+				return fmt.Errorf("--all cannot be combined with --continue or --abort")
+			}
 
 			c, err := cli.CommonFromCmd(cmd)
 			if err != nil {
@@ -60,14 +71,48 @@ func syncCmd() *cobra.Command {
 				forcePushOverride = &v
 			}
 
-			out, err := workflow.Sync(res.Config, workflow.SyncOptions{
+			var autostashOverride *bool
+			if cmd.Flags().Changed("autostash") {
+				v := autostash
+				autostashOverride = &v
+			}
+
+			opts := workflow.SyncOptions{
 				RepoPath:          repoPath,
 				Remote:            remote,
 				StrategyOverride:  strategy,
 				AutoPushOverride:  autoPushOverride,
 				ForcePushOverride: forcePushOverride,
-			})
+				AutostashOverride: autostashOverride,
+			}
+
+			if all {
+				out, err := workflow.SyncAll(res.Config, opts)
+				if err != nil {
+					return err
+				}
+				return printSyncAll(cmd, c, out)
+			}
+
+			if abort {
+				out, err := workflow.SyncAbort(res.Config, opts)
+				if err != nil {
+					return err
+				}
+				c.UI.Header("Sync aborted")
+				c.UI.Line("Base branch: %s", out.BaseBranch)
+				c.UI.Line("Current branch: %s", out.CurrentBranch)
+				c.UI.Success("Branch restored to its state before sync")
+				cli.PrintAutostash(c.UI, out.Autostash)
+				return nil
+			}
 
+			var out *workflow.SyncResult
+			if cont {
+				out, err = workflow.SyncContinue(res.Config, opts)
+			} else {
+				out, err = workflow.Sync(res.Config, opts)
+			}
 			if err != nil {
 				return err
 			}
@@ -76,8 +121,15 @@ func syncCmd() *cobra.Command {
 			cli.PrintConfigSource(c.UI, c.ConfigResult.Path)
 
 			c.UI.Line("Base branch: %s", out.BaseBranch)
+			c.UI.Line("Synced onto: %s", out.BaseRef)
 			c.UI.Line("Current branch: %s", out.CurrentBranch)
 			c.UI.Line("Strategy: %s", out.Strategy)
+			if out.BaseUpdated {
+				c.UI.Line("Local %s fast-forwarded", out.BaseBranch)
+			}
+			for _, w := range out.Warnings {
+				c.UI.Warn("%s", w)
+			}
 
 			if out.Pushed {
 				if out.ForcePushed {
@@ -88,6 +140,7 @@ func syncCmd() *cobra.Command {
 			} else {
 				c.UI.Warn("Remote: not pushed")
 			}
+			cli.PrintAutostash(c.UI, out.Autostash)
 
 			return nil
 		},
@@ -98,6 +151,59 @@ func syncCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&rebase, "rebase", false, "Use rebase strategy")
 	cmd.Flags().BoolVar(&noPush, "no-push", false, "Do not push after syncing")
 	cmd.Flags().BoolVar(&force, "force", false, "Allow force with lease when rebasing and pushing")
+	cmd.Flags().BoolVar(&cont, "continue", false, "Continue a sync that stopped on conflicts")
+	cmd.Flags().BoolVar(&abort, "abort", false, "Abort an in-progress sync and restore the branch")
+	cmd.Flags().BoolVar(&all, "all", false, "Sync every local branch matching the configured prefixes")
+	cmd.Flags().BoolVar(&autostash, "autostash", false, "Stash local changes before syncing and restore them after")
 
 	return cmd
 }
+
+// printSyncAll prints the summary and fails when a synced branch could not be
+// pushed, so scripts notice that the remote is behind.
+func printSyncAll(cmd *cobra.Command, c *cli.Common, out *workflow.SyncAllResult) error {
+	c.UI.Header("Sync all branches")
+	cli.PrintConfigSource(c.UI, c.ConfigResult.Path)
+
+	c.UI.Line("Base branch: %s", out.BaseBranch)
+	if out.BaseRef != "" {
+		c.UI.Line("Synced onto: %s", out.BaseRef)
+	}
+	c.UI.Line("Strategy: %s", out.Strategy)
+	for _, w := range out.Warnings {
+		c.UI.Warn("%s", w)
+	}
+	c.UI.Line("")
+
+	if len(out.Branches) == 0 {
+		c.UI.Success("No branches match the configured prefixes")
+		return nil
+	}
+
+	counts := make(map[string]int)
+	t := ui.NewTable(cmd.OutOrStdout())
+	t.Header("BRANCH", "STATUS", "PUSHED", "DETAIL")
+	for _, b := range out.Branches {
+		counts[b.Status]++
+		pushed := ""
+		if b.Pushed {
+			pushed = "yes"
+		}
+		t.Row(b.Name, b.Status, pushed, b.Detail)
+	}
+	t.Flush()
+
+	c.UI.Line("")
+	c.UI.Line("Updated: %d  Up to date: %d  Conflicted: %d",
+		counts[workflow.SyncUpdated], counts[workflow.SyncUpToDate], counts[workflow.SyncConflicted])
+	if n := counts[workflow.SyncSkipped]; n > 0 {
+		c.UI.Warn("Skipped: %d", n)
+	}
+	c.UI.Line("Back on: %s", out.Original)
+	cli.PrintAutostash(c.UI, out.Autostash)
+
+	if n := counts[workflow.SyncPushFailed]; n > 0 {
+		return fmt.Errorf("%d branch(es) were updated locally but could not be pushed", n)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package root
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"gitflow/internal/cli"
+	"gitflow/internal/workflow"
+)
+
+func tidyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tidy",
+		Short: "Squash fixup commits with an autosquash rebase onto the base branch",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := cli.CommonFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			repoPath, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+
+			out, err := workflow.Tidy(c.ConfigResult.Config, workflow.TidyOptions{
+				RepoPath: repoPath,
+			})
+			if err != nil {
+				return err
+			}
+
+			c.UI.Header("Tidy branch")
+			cli.PrintConfigSource(c.UI, c.ConfigResult.Path)
+
+			c.UI.Line("Base branch: %s", out.BaseBranch)
+			c.UI.Line("Current branch: %s", out.CurrentBranch)
+			c.UI.Line("Commits: %d -> %d", out.Before, out.After)
+			if out.Before == out.After {
+				c.UI.Success("Nothing to squash")
+			} else {
+				c.UI.Success("Squashed %d commits", out.Before-out.After)
+			}
+
+			return nil
+		},
+	}
+}
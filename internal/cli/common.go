@@ -16,11 +16,16 @@ type Common struct {
 	UI           *ui.UI
 }
 
-// UIOverrides applies runtime UI overrides to config.
+// UIOverrides applies runtime UI overrides to config. The flag names are
+// recorded as the origin of each override.
 type UIOverrides struct {
 	Color   *bool
 	Emoji   *bool
 	Verbose *bool
+
+	ColorFlag   string
+	EmojiFlag   string
+	VerboseFlag string
 }
 
 var uiOverrides UIOverrides
@@ -37,25 +42,26 @@ func CommonFromCmd(cmd *cobra.Command) (*Common, error) {
 		return nil, err
 	}
 
-	colorEnabled := res.Config.UI.Color
-	emojiEnabled := res.Config.UI.Emoji
-	verboseEnabled := res.Config.UI.Verbose
+	// Flags are the last config layer.
 	if uiOverrides.Color != nil {
-		colorEnabled = *uiOverrides.Color
+		res.Config.UI.Color = *uiOverrides.Color
+		res.SetOrigin("ui.color", config.Origin{Layer: config.LayerFlag, Source: flagSource(uiOverrides.ColorFlag)})
 	}
 	if uiOverrides.Emoji != nil {
-		emojiEnabled = *uiOverrides.Emoji
+		res.Config.UI.Emoji = *uiOverrides.Emoji
+		res.SetOrigin("ui.emoji", config.Origin{Layer: config.LayerFlag, Source: flagSource(uiOverrides.EmojiFlag)})
 	}
 	if uiOverrides.Verbose != nil {
-		verboseEnabled = *uiOverrides.Verbose
+		res.Config.UI.Verbose = *uiOverrides.Verbose
+		res.SetOrigin("ui.verbose", config.Origin{Layer: config.LayerFlag, Source: flagSource(uiOverrides.VerboseFlag)})
 	}
 
 	out := cmd.OutOrStdout()
 	u := ui.New(ui.Options{
 		Out:     out,
-		Color:   colorEnabled,
-		Emoji:   emojiEnabled,
-		Verbose: verboseEnabled,
+		Color:   res.Config.UI.Color,
+		Emoji:   res.Config.UI.Emoji,
+		Verbose: res.Config.UI.Verbose,
 	})
 
 	return &Common{
@@ -64,6 +70,13 @@ func CommonFromCmd(cmd *cobra.Command) (*Common, error) {
 	}, nil
 }
 
+func flagSource(name string) string {
+	if name == "" {
+		return "flag"
+	}
+	return "--" + name
+}
+
 func outWriter(cmd *cobra.Command) io.Writer {
 	return cmd.OutOrStdout()
 }
@@ -31,7 +31,7 @@ func TestNoColorOverridesConfig(t *testing.T) {
 	}
 
 	color := false
-	SetUIOverrides(UIOverrides{Color: &color})
+	SetUIOverrides(UIOverrides{Color: &color, ColorFlag: "no-color"})
 	defer SetUIOverrides(UIOverrides{})
 
 	var buf bytes.Buffer
@@ -45,6 +45,9 @@ func TestNoColorOverridesConfig(t *testing.T) {
 	if common.UI.ColorEnabled() {
 		t.Fatalf("expected color override to disable output")
 	}
+	if origin := common.ConfigResult.Origins["ui.color"]; origin.Source != "--no-color" {
+		t.Fatalf("expected ui.color to come from --no-color, got %q", origin.Source)
+	}
 }
 
 func TestEmojiOverridesConfig(t *testing.T) {
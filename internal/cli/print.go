@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"gitflow/internal/ui"
 )
@@ -18,6 +19,16 @@ func ConfigSource(path string) string {
 	return path
 }
 
+// WriteJSON prints payload as a single line of JSON.
+func WriteJSON(u *ui.UI, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	u.Line("%s", string(data))
+	return nil
+}
+
 // EnsureOneKind ensures only one of bugfix or hotfix is selected.
 func EnsureOneKind(bugfix bool, hotfix bool) error {
 	if bugfix && hotfix {
@@ -25,3 +36,23 @@ func EnsureOneKind(bugfix bool, hotfix bool) error {
 	}
 	return nil
 }
+
+// AutostashReport describes changes stashed around a workflow. Summary is
+// empty once they were restored.
+type AutostashReport interface {
+	Stashed() bool
+	Summary() string
+}
+
+// PrintAutostash reports how changes stashed around a workflow were handled.
+// It prints nothing when nothing was stashed.
+func PrintAutostash(u *ui.UI, r AutostashReport) {
+	if r == nil || !r.Stashed() {
+		return
+	}
+	if summary := r.Summary(); summary != "" {
+		u.Warn("Autostash: %s", summary)
+		return
+	}
+	u.Success("Autostash: changes restored")
+}
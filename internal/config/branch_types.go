@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// DefaultBranchTemplate names branches of configured types without a template.
+const DefaultBranchTemplate = "{{.Type}}/{{.Slug}}"
+
+func validateBranchNaming(b BranchConfig) error {
+	if b.NamePattern != "" {
+		if _, err := regexp.Compile(b.NamePattern); err != nil {
+			return fmt.Errorf("branches.name_pattern: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, t := range b.Types {
+		name := strings.TrimSpace(t.Name)
+		if name == "" {
+			return fmt.Errorf("branches.types[%d].name is required", i)
+		}
+		if seen[name] {
+			return fmt.Errorf("branches.types: duplicate type %s", name)
+		}
+		seen[name] = true
+		if t.Template == "" {
+			continue
+		}
+		if _, err := template.New(name).Option("missingkey=error").Parse(t.Template); err != nil {
+			return fmt.Errorf("branches.types[%s].template: %w", name, err)
+		}
+		if !strings.Contains(t.Template, ".Slug") {
+			return fmt.Errorf("branches.types[%s].template must use {{.Slug}}", name)
+		}
+	}
+	return nil
+}
@@ -2,10 +2,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
-
-	"gopkg.in/yaml.v3"
+	"strings"
 )
 
 // Config is the top-level gitflow configuration.
@@ -34,6 +34,26 @@ type BranchConfig struct {
 	HotfixPrefix  string `yaml:"hotfix_prefix"`
 	MainBranch    string `yaml:"main_branch"`
 	DevelopBranch string `yaml:"develop_branch"`
+
+	// Protected lists branch names, globs or regexes that must not be
+	// force-pushed, committed to directly or cleaned up.
+	Protected []string `yaml:"protected"`
+
+	// Types adds or overrides branch types for gitflow start --type.
+	Types []BranchType `yaml:"types"`
+	// MaxLength caps generated branch names by shortening the slug; 0 disables it.
+	MaxLength int `yaml:"max_length"`
+	// NamePattern is a regex every generated branch name must match.
+	NamePattern string `yaml:"name_pattern"`
+}
+
+// BranchType describes how branches of one kind are named and where they start.
+type BranchType struct {
+	Name string `yaml:"name"`
+	// Template is a text/template using .User, .Type, .Issue and .Slug.
+	// It defaults to {{.Type}}/{{.Slug}}.
+	Template   string `yaml:"template"`
+	BaseBranch string `yaml:"base_branch"`
 }
 
 // WorkflowConfig groups workflow-specific settings.
@@ -42,6 +62,8 @@ type WorkflowConfig struct {
 	PR      PRConfig      `yaml:"pr"`
 	Sync    SyncConfig    `yaml:"sync"`
 	Cleanup CleanupConfig `yaml:"cleanup"`
+
+	Autostash bool `yaml:"autostash"`
 }
 
 // StartConfig governs the start workflow behavior.
@@ -94,10 +116,19 @@ type ReleaseConfig struct {
 	TagPrefix         string   `yaml:"tag_prefix"`
 }
 
-// LoadResult captures the config and its source path.
+// LoadResult captures the merged config and where its values came from.
 type LoadResult struct {
 	Config *Config
-	Path   string
+	// Path is the most specific config file merged, empty when only
+	// defaults and environment variables apply.
+	Path string
+	// Layers lists the merged config files from lowest to highest precedence.
+	Layers []Layer
+	// Origins maps dotted keys such as workflows.sync.strategy to the layer
+	// that last set them. Keys left at their defaults are absent.
+	Origins map[string]Origin
+	// Env lists the environment variables that override config keys.
+	Env []EnvVar
 }
 
 // Load searches for a config starting from the working directory.
@@ -110,44 +141,61 @@ func Load() (*LoadResult, error) {
 	return LoadFromDir(startDir)
 }
 
-// LoadFromDir searches for a config starting from startDir.
+// LoadFromDir merges the system, user, repo and local config files for
+// startDir over the defaults, then applies environment overrides.
 func LoadFromDir(startDir string) (*LoadResult, error) {
-	path, err := findConfig(startDir)
-	if err != nil {
-		cfg := Default()
-		if err := ApplyEnvOverrides(cfg); err != nil {
+	res := &LoadResult{Config: Default(), Origins: make(map[string]Origin)}
+
+	for _, layer := range configLayers(startDir) {
+		if err := mergeFile(res.Config, layer, res.Origins); err != nil {
 			return nil, err
 		}
-		return &LoadResult{Path: "", Config: cfg}, nil
+		res.Layers = append(res.Layers, layer)
+		res.Path = layer.Path
 	}
-
-	cfg, err := loadFromPath(path)
-	if err != nil {
+	if err := res.Config.Validate(); err != nil {
 		return nil, err
 	}
-	if err := ApplyEnvOverrides(cfg); err != nil {
+
+	if err := ApplyEnvOverrides(res.Config); err != nil {
 		return nil, err
 	}
+	res.Env = EnvVars()
+	if len(res.Env) == 0 {
+		return res, nil
+	}
+	for _, v := range res.Env {
+		res.Origins[v.Key] = Origin{Layer: LayerEnv, Source: v.Name}
+	}
+	// The files were valid, so a failure now comes from the environment.
+	if err := res.Config.Validate(); err != nil {
+		return nil, envValidationError(res, err)
+	}
 
-	return &LoadResult{Path: path, Config: cfg}, nil
+	return res, nil
 }
 
-func loadFromPath(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config at %s: %w", path, err)
-	}
-
-	cfg := Default()
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse yaml at %s: %w", path, err)
+// envValidationError prefixes each failed check with the variable that set
+// its key. A check that cannot be traced to one variable names all of them.
+func envValidationError(res *LoadResult, err error) error {
+	names := make([]string, 0, len(res.Env))
+	for _, v := range res.Env {
+		names = append(names, v.Name)
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return nil, err
+	lines := strings.Split(err.Error(), "\n")
+	for i, line := range lines {
+		key := line
+		if end := strings.IndexAny(line, " :["); end >= 0 {
+			key = line[:end]
+		}
+		source := strings.Join(names, ", ")
+		if origin, ok := res.Origins[key]; ok && origin.Layer == LayerEnv {
+			source = origin.Source
+		}
+		lines[i] = source + ": " + line
 	}
-
-	return cfg, nil
+	return errors.New(strings.Join(lines, "\n"))
 }
 
 // Validate fills defaults and checks for invalid settings.
@@ -187,18 +235,19 @@ func (c *Config) Validate() error {
 		c.Release.ChangelogSections = []string{"breaking", "features", "fixes", "other"}
 	}
 
-	if c.Provider.Type != "" && c.Provider.Type != "github" && c.Provider.Type != "gitlab" {
-		return fmt.Errorf("unsupported provider type: %s", c.Provider.Type)
+	if errs := schemaErrors(c); len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
 	}
 
-	if c.Workflows.Sync.Strategy != "rebase" && c.Workflows.Sync.Strategy != "merge" {
-		return fmt.Errorf("unsupported sync strategy: %s", c.Workflows.Sync.Strategy)
+	if _, err := NewBranchMatcher(c.Branches.Protected...); err != nil {
+		return fmt.Errorf("branches.protected: %w", err)
 	}
-
-	switch c.Release.DefaultBump {
-	case "major", "minor", "patch":
-	default:
-		return fmt.Errorf("unsupported release default bump: %s", c.Release.DefaultBump)
+	if err := validateBranchNaming(c.Branches); err != nil {
+		return err
+	}
+	if _, err := NewBranchMatcher(c.Workflows.Cleanup.ProtectedBranches...); err != nil {
+		return fmt.Errorf("workflows.cleanup.protected_branches: %w", err)
 	}
+
 	return nil
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -57,3 +58,76 @@ func TestValidateRejectBadSyncStrategy(t *testing.T) {
 		t.Fatalf("expected validation error")
 	}
 }
+
+func TestLoadMergesLayers(t *testing.T) {
+	system := t.TempDir()
+	xdg := t.TempDir()
+	repo := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("GITFLOW_RELEASE_TAG_PREFIX", "rel-")
+
+	prev := systemConfigDir
+	systemConfigDir = system
+	t.Cleanup(func() { systemConfigDir = prev })
+
+	files := map[string]string{
+		filepath.Join(system, "config.yml"):         "release:\n  default_bump: minor\n  tag_prefix: sys-\nui:\n  emoji: true\n",
+		filepath.Join(xdg, "gitflow", "config.yml"): "ui:\n  emoji: false\n  verbose: true\n",
+		filepath.Join(repo, ".gitflow.yml"):         "branches:\n  main_branch: trunk\ncommits:\n  scopes: [api, cli]\n",
+		filepath.Join(repo, ".gitflow.local.yml"):   "commits:\n  scopes: [docs]\n",
+	}
+	for path, data := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+
+	res, err := LoadFromDir(repo)
+	if err != nil {
+		t.Fatalf("LoadFromDir: %v", err)
+	}
+	cfg := res.Config
+	if cfg.Release.DefaultBump != "minor" || cfg.Release.TagPrefix != "rel-" {
+		t.Fatalf("unexpected release config: %+v", cfg.Release)
+	}
+	if cfg.UI.Emoji || !cfg.UI.Verbose || !cfg.UI.Color {
+		t.Fatalf("unexpected ui config: %+v", cfg.UI)
+	}
+	if cfg.Branches.MainBranch != "trunk" || cfg.Workflows.Start.BaseBranch != "main" {
+		t.Fatalf("unexpected branches: main %s start %s", cfg.Branches.MainBranch, cfg.Workflows.Start.BaseBranch)
+	}
+	if len(cfg.Commits.Scopes) != 1 || cfg.Commits.Scopes[0] != "docs" {
+		t.Fatalf("expected local scopes to replace repo scopes, got %v", cfg.Commits.Scopes)
+	}
+	if res.Path != filepath.Join(repo, ".gitflow.local.yml") || len(res.Layers) != 4 {
+		t.Fatalf("unexpected layers: path %s layers %+v", res.Path, res.Layers)
+	}
+
+	want := map[string]string{
+		"release.default_bump": LayerSystem,
+		"release.tag_prefix":   LayerEnv,
+		"ui.emoji":             LayerUser,
+		"branches.main_branch": LayerRepo,
+		"commits.scopes":       LayerLocal,
+	}
+	for key, layer := range want {
+		if got := res.Origins[key].Layer; got != layer {
+			t.Fatalf("expected %s from %s, got %q", key, layer, got)
+		}
+	}
+	if _, ok := res.Origins["ui.color"]; ok {
+		t.Fatalf("expected ui.color to keep its default")
+	}
+
+	out, err := MarshalWithOrigins(cfg, res.Origins)
+	if err != nil {
+		t.Fatalf("MarshalWithOrigins: %v", err)
+	}
+	if !strings.Contains(string(out), "tag_prefix: rel- # env GITFLOW_RELEASE_TAG_PREFIX") {
+		t.Fatalf("expected env origin comment, got:\n%s", out)
+	}
+}
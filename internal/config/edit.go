@@ -0,0 +1,315 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScopePath returns the config file that set, get and unset edit for a
+// scope: LayerRepo, LayerUser or LayerLocal. Existing files are preferred;
+// otherwise the path a new file would be written to is returned.
+func ScopePath(scope, startDir string) (string, error) {
+	root := startDir
+	if top, err := gitTopLevel(startDir); err == nil {
+		root = top
+	}
+
+	switch scope {
+	case LayerRepo:
+		for _, dir := range []string{startDir, root} {
+			if p, ok := findConfigInDir(dir); ok {
+				return p, nil
+			}
+		}
+		return filepath.Join(root, ".gitflow.yml"), nil
+	case LayerLocal:
+		for _, dir := range []string{startDir, root} {
+			if p, ok := findNamedConfig(dir, ".gitflow.local"); ok {
+				return p, nil
+			}
+		}
+		return filepath.Join(root, ".gitflow.local.yml"), nil
+	case LayerUser:
+		if p := userConfigPath(); p != "" {
+			return p, nil
+		}
+		xdg := os.Getenv("XDG_CONFIG_HOME")
+		if xdg == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to find home directory: %w", err)
+			}
+			xdg = filepath.Join(home, ".config")
+		}
+		return filepath.Join(xdg, "gitflow", "config.yml"), nil
+	default:
+		return "", fmt.Errorf("unsupported scope %q: use repo, user or local", scope)
+	}
+}
+
+// Lookup returns the value of a dotted key such as workflows.sync.strategy.
+func Lookup(cfg *Config, key string) (any, error) {
+	v, err := fieldByKey(cfg, key)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+func fieldByKey(cfg *Config, key string) (reflect.Value, error) {
+	all := fields(cfg)
+	for _, f := range all {
+		if f.Key == key {
+			return f.Value, nil
+		}
+	}
+
+	for _, f := range all {
+		if strings.EqualFold(f.Key, key) {
+			return reflect.Value{}, fmt.Errorf("unknown config key %q (did you mean %s?)", key, f.Key)
+		}
+	}
+	for _, f := range all {
+		if strings.HasPrefix(f.Key, key+".") {
+			return reflect.Value{}, fmt.Errorf("%s is a section; use one of its keys such as %s", key, f.Key)
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("unknown config key %q", key)
+}
+
+// File is a config file edited through its YAML node tree, so comments and
+// key order survive a rewrite.
+type File struct {
+	Path   string
+	doc    yaml.Node
+	indent int
+}
+
+// OpenFile reads a config file for editing. A missing file opens empty and
+// is created by Save.
+func OpenFile(path string) (*File, error) {
+	f := &File{Path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config at %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &f.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml at %s: %w", path, err)
+	}
+	f.indent = detectIndent(data)
+	return f, nil
+}
+
+// detectIndent returns the indentation step of a YAML file: the smallest
+// indent of a nested mapping key, or of a sequence item when there are none.
+// It returns 0 when nothing is indented.
+func detectIndent(data []byte) int {
+	keys, items := 0, 0
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		n := len(line) - len(trimmed)
+		if n == 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if items == 0 || n < items {
+				items = n
+			}
+			continue
+		}
+		if keys == 0 || n < keys {
+			keys = n
+		}
+	}
+	if keys > 0 {
+		return keys
+	}
+	return items
+}
+
+// root returns the top-level mapping, creating it when create is set.
+func (f *File) root(create bool) *yaml.Node {
+	if len(f.doc.Content) == 0 {
+		if !create {
+			return nil
+		}
+		f.doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := f.doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	return root
+}
+
+// Get returns the value the file sets for key, rendered as YAML, and
+// whether the file sets it.
+func (f *File) Get(key string) (string, bool, error) {
+	if _, err := fieldByKey(Default(), key); err != nil {
+		return "", false, err
+	}
+	node := f.root(false)
+	for _, part := range strings.Split(key, ".") {
+		if node == nil {
+			return "", false, nil
+		}
+		_, node = mappingEntry(node, part)
+	}
+	if node == nil {
+		return "", false, nil
+	}
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, true, nil
+	}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(string(out)), true, nil
+}
+
+// Set parses raw for the key's type, as environment variables are, and
+// stores it. Existing comments on the value are kept, and missing sections
+// are added at the end of their parent.
+func (f *File) Set(key, raw string) error {
+	field, err := fieldByKey(Default(), key)
+	if err != nil {
+		return err
+	}
+	value := reflect.New(field.Type()).Elem()
+	if err := setFromString(value, raw); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	if values, ok := schemaEnums[key]; ok && !containsString(values, value.String()) {
+		return fmt.Errorf("invalid value for %s: must be %s", key, joinChoices(values))
+	}
+
+	var encoded yaml.Node
+	if err := encoded.Encode(value.Interface()); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	node := f.root(true)
+	if node == nil {
+		return fmt.Errorf("%s does not contain a YAML mapping", f.Path)
+	}
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		_, child := mappingEntry(node, part)
+		if child == nil || child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingEntry(node, part, child)
+		}
+		node = child
+	}
+
+	last := parts[len(parts)-1]
+	if _, old := mappingEntry(node, last); old != nil {
+		encoded.HeadComment = old.HeadComment
+		encoded.LineComment = old.LineComment
+		encoded.FootComment = old.FootComment
+	}
+	setMappingEntry(node, last, &encoded)
+	return nil
+}
+
+// Unset removes key from the file, along with sections it leaves empty. It
+// reports whether the file set the key.
+func (f *File) Unset(key string) (bool, error) {
+	if _, err := fieldByKey(Default(), key); err != nil {
+		return false, err
+	}
+	root := f.root(false)
+	if root == nil {
+		return false, nil
+	}
+	return unsetPath(root, strings.Split(key, ".")), nil
+}
+
+func unsetPath(node *yaml.Node, parts []string) bool {
+	i, child := mappingEntry(node, parts[0])
+	if child == nil {
+		return false
+	}
+	if len(parts) > 1 {
+		if child.Kind != yaml.MappingNode || !unsetPath(child, parts[1:]) {
+			return false
+		}
+		if len(child.Content) > 0 {
+			return true
+		}
+	}
+	node.Content = append(node.Content[:i], node.Content[i+2:]...)
+	return true
+}
+
+// Save validates the edited file on its own and writes it. Nothing is
+// written when validation fails.
+func (f *File) Save() error {
+	var buf bytes.Buffer
+	if root := f.root(false); root != nil && len(root.Content) > 0 {
+		indent := f.indent
+		if indent == 0 {
+			indent = 2
+		}
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(indent)
+		if err := enc.Encode(&f.doc); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+		if err := enc.Close(); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+	}
+
+	cfg := Default()
+	if err := yaml.Unmarshal(buf.Bytes(), cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(f.Path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// mappingEntry finds key in a mapping node and returns the index of its key
+// node and its value, or -1 and nil.
+func mappingEntry(node *yaml.Node, key string) (int, *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return -1, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i, node.Content[i+1]
+		}
+	}
+	return -1, nil
+}
+
+func setMappingEntry(node *yaml.Node, key string, value *yaml.Node) {
+	if i, _ := mappingEntry(node, key); i >= 0 {
+		node.Content[i+1] = value
+		return
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
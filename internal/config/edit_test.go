@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSetKeepsCommentsAndOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitflow.yml")
+	data := "# Team config\nrelease:\n  tag_prefix: v # keep the v\nui:\n  emoji: true\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	for key, value := range map[string]string{
+		"release.tag_prefix":      "rel-",
+		"workflows.sync.strategy": "merge",
+		"branches.protected":      "main, release/*",
+	} {
+		if err := f.Set(key, value); err != nil {
+			t.Fatalf("Set %s: %v", key, err)
+		}
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	got := string(raw)
+	for _, want := range []string{"# Team config", "tag_prefix: rel- # keep the v", "    strategy: merge", "    - release/*"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "release:") > strings.Index(got, "ui:") || strings.Index(got, "ui:") > strings.Index(got, "workflows:") {
+		t.Fatalf("expected existing keys to keep their order:\n%s", got)
+	}
+
+	res, err := LoadFromDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("LoadFromDir: %v", err)
+	}
+	if res.Config.Workflows.Sync.Strategy != "merge" || len(res.Config.Branches.Protected) != 2 {
+		t.Fatalf("unexpected config after set: %+v", res.Config)
+	}
+}
+
+func TestFileSetKeepsIndentAndFlowStyle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitflow.yml")
+	data := "commits:\n    conventional: true\n    types: [feat, fix]\nui:\n    emoji: true\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if err := f.Set("ui.emoji", "false"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	want := strings.Replace(data, "emoji: true", "emoji: false", 1)
+	if string(raw) != want {
+		t.Fatalf("expected only the edited value to change, got:\n%s", raw)
+	}
+}
+
+func TestFileSetValidatesTypes(t *testing.T) {
+	f, err := OpenFile(filepath.Join(t.TempDir(), ".gitflow.yml"))
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+
+	cases := map[string]string{
+		"ui.verbose":                           "maybe",
+		"workflows.cleanup.age_threshold_days": "soon",
+		"workflows.sync.strategy":              "squash",
+		"workflows.sync.stratgy":               "merge",
+		"workflows.sync":                       "merge",
+	}
+	for key, value := range cases {
+		if err := f.Set(key, value); err == nil {
+			t.Fatalf("expected error setting %s to %q", key, value)
+		}
+	}
+
+	if err := f.Set("branches.name_pattern", "(["); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := f.Save(); err == nil {
+		t.Fatalf("expected Save to reject an invalid regex")
+	}
+	if _, err := os.Stat(f.Path); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing to be written, got %v", err)
+	}
+}
+
+func TestFileUnsetRemovesEmptySections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitflow.yml")
+	data := "workflows:\n  sync:\n    strategy: merge\nui:\n  emoji: true\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	f, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	removed, err := f.Unset("workflows.sync.strategy")
+	if err != nil || !removed {
+		t.Fatalf("Unset: removed %t err %v", removed, err)
+	}
+	if removed, _ := f.Unset("workflows.sync.strategy"); removed {
+		t.Fatalf("expected second unset to report nothing removed")
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if string(raw) != "ui:\n  emoji: true\n" {
+		t.Fatalf("unexpected file after unset:\n%s", raw)
+	}
+}
+
+func TestScopePath(t *testing.T) {
+	dir := t.TempDir()
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("HOME", t.TempDir())
+
+	want := map[string]string{
+		LayerRepo:  filepath.Join(dir, ".gitflow.yml"),
+		LayerLocal: filepath.Join(dir, ".gitflow.local.yml"),
+		LayerUser:  filepath.Join(xdg, "gitflow", "config.yml"),
+	}
+	for scope, path := range want {
+		got, err := ScopePath(scope, dir)
+		if err != nil {
+			t.Fatalf("ScopePath %s: %v", scope, err)
+		}
+		if got != path {
+			t.Fatalf("scope %s: got %s want %s", scope, got, path)
+		}
+	}
+	if _, err := ScopePath("system", dir); err == nil {
+		t.Fatalf("expected system scope to be rejected")
+	}
+}
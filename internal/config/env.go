@@ -3,51 +3,124 @@ package config
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
-// ApplyEnvOverrides overrides config values from environment variables.
+// envPrefix starts every config environment variable.
+const envPrefix = "GITFLOW_"
+
+// envNoColor is kept for compatibility; it inverts ui.color.
+const envNoColor = "GITFLOW_UI_NO_COLOR"
+
+// EnvVar is an environment variable that overrides a config key.
+type EnvVar struct {
+	Name  string
+	Key   string
+	Value string
+}
+
+// EnvName returns the environment variable for a dotted config key, for
+// example GITFLOW_WORKFLOWS_SYNC_STRATEGY for workflows.sync.strategy.
+func EnvName(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// EnvVars lists the config overrides set in the environment, sorted by name.
+func EnvVars() []EnvVar {
+	var vars []EnvVar
+	for _, f := range fields(Default()) {
+		name := EnvName(f.Key)
+		if value, ok := os.LookupEnv(name); ok {
+			vars = append(vars, EnvVar{Name: name, Key: f.Key, Value: value})
+		}
+	}
+	if value, ok := os.LookupEnv(envNoColor); ok {
+		vars = append(vars, EnvVar{Name: envNoColor, Key: "ui.color", Value: value})
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+// ApplyEnvOverrides sets every config key that has a GITFLOW_ variable in
+// the environment. Lists are split on commas; other values are parsed for
+// the key's type, and parse errors name the variable.
 func ApplyEnvOverrides(cfg *Config) error {
 	if cfg == nil {
 		return fmt.Errorf("config is nil")
 	}
 
-	if value, ok := os.LookupEnv("GITFLOW_RELEASE_TAG_PREFIX"); ok {
-		cfg.Release.TagPrefix = strings.TrimSpace(value)
+	byKey := make(map[string]reflect.Value)
+	for _, f := range fields(cfg) {
+		byKey[f.Key] = f.Value
 	}
-	if value, ok := os.LookupEnv("GITFLOW_RELEASE_DEFAULT_BUMP"); ok {
-		cfg.Release.DefaultBump = strings.TrimSpace(strings.ToLower(value))
+
+	for _, v := range EnvVars() {
+		if v.Name == envNoColor {
+			continue
+		}
+		if err := setFromString(byKey[v.Key], v.Value); err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Name, err)
+		}
 	}
-	if value, ok := os.LookupEnv("GITFLOW_UI_NO_COLOR"); ok {
-		enabled, err := strconv.ParseBool(value)
+	// The older negative form wins over GITFLOW_UI_COLOR, as it always has.
+	if value, ok := os.LookupEnv(envNoColor); ok {
+		disabled, err := strconv.ParseBool(strings.TrimSpace(value))
 		if err != nil {
-			return fmt.Errorf("invalid GITFLOW_UI_NO_COLOR: %w", err)
+			return fmt.Errorf("invalid %s: expected true or false, got %q", envNoColor, value)
 		}
-		cfg.UI.Color = !enabled
+		cfg.UI.Color = !disabled
 	}
-	if value, ok := os.LookupEnv("GITFLOW_UI_EMOJI"); ok {
-		enabled, err := strconv.ParseBool(value)
+
+	// Validate checks the result against the schema enums.
+	cfg.Release.DefaultBump = strings.ToLower(cfg.Release.DefaultBump)
+	return nil
+}
+
+// setFromString parses raw for the type of v. Structured values such as
+// branches.types are read as YAML or JSON.
+func setFromString(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(strings.TrimSpace(raw))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
 		if err != nil {
-			return fmt.Errorf("invalid GITFLOW_UI_EMOJI: %w", err)
+			return fmt.Errorf("expected true or false, got %q", raw)
 		}
-		cfg.UI.Emoji = enabled
-	}
-	if value, ok := os.LookupEnv("GITFLOW_UI_VERBOSE"); ok {
-		enabled, err := strconv.ParseBool(value)
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
 		if err != nil {
-			return fmt.Errorf("invalid GITFLOW_UI_VERBOSE: %w", err)
+			return fmt.Errorf("expected an integer, got %q", raw)
 		}
-		cfg.UI.Verbose = enabled
-	}
-
-	switch cfg.Release.DefaultBump {
-	case "major", "minor", "patch":
-	case "":
-		cfg.Release.DefaultBump = "patch"
+		v.SetInt(int64(n))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return setFromYAML(v, raw)
+		}
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
 	default:
-		return fmt.Errorf("unsupported release default bump: %s", cfg.Release.DefaultBump)
+		return setFromYAML(v, raw)
 	}
+	return nil
+}
 
+func setFromYAML(v reflect.Value, raw string) error {
+	target := reflect.New(v.Type())
+	if err := yaml.Unmarshal([]byte(raw), target.Interface()); err != nil {
+		return fmt.Errorf("expected YAML or JSON for %s: %w", v.Type(), err)
+	}
+	v.Set(target.Elem())
 	return nil
 }
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -28,3 +30,55 @@ func TestEnvOverridesConfig(t *testing.T) {
 		t.Fatalf("expected env override tag prefix, got %s", res.Config.Release.TagPrefix)
 	}
 }
+
+func TestEnvOverridesDerivedFromYAMLTags(t *testing.T) {
+	t.Setenv("GITFLOW_WORKFLOWS_SYNC_STRATEGY", "merge")
+	t.Setenv("GITFLOW_PROVIDER_OWNER", " acme ")
+	t.Setenv("GITFLOW_BRANCHES_PROTECTED", "release/*, env/prod,")
+	t.Setenv("GITFLOW_WORKFLOWS_CLEANUP_AGE_THRESHOLD_DAYS", "7")
+	t.Setenv("GITFLOW_WORKFLOWS_AUTOSTASH", "true")
+	t.Setenv("GITFLOW_BRANCHES_TYPES", `[{name: spike, template: "spike/{{.Slug}}"}]`)
+
+	cfg := Default()
+	if err := ApplyEnvOverrides(cfg); err != nil {
+		t.Fatalf("ApplyEnvOverrides: %v", err)
+	}
+	if cfg.Workflows.Sync.Strategy != "merge" || cfg.Provider.Owner != "acme" {
+		t.Fatalf("unexpected strings: strategy %q owner %q", cfg.Workflows.Sync.Strategy, cfg.Provider.Owner)
+	}
+	if !reflect.DeepEqual(cfg.Branches.Protected, []string{"release/*", "env/prod"}) {
+		t.Fatalf("unexpected list: %q", cfg.Branches.Protected)
+	}
+	if cfg.Workflows.Cleanup.AgeThresholdDays != 7 || !cfg.Workflows.Autostash {
+		t.Fatalf("unexpected cleanup age %d autostash %t", cfg.Workflows.Cleanup.AgeThresholdDays, cfg.Workflows.Autostash)
+	}
+	if len(cfg.Branches.Types) != 1 || cfg.Branches.Types[0].Template != "spike/{{.Slug}}" {
+		t.Fatalf("unexpected branch types: %+v", cfg.Branches.Types)
+	}
+
+	names := []string{}
+	for _, v := range EnvVars() {
+		names = append(names, v.Name)
+	}
+	if len(names) != 6 || names[0] != "GITFLOW_BRANCHES_PROTECTED" {
+		t.Fatalf("unexpected env vars: %v", names)
+	}
+}
+
+func TestEnvOverrideErrorsNameVariable(t *testing.T) {
+	t.Setenv("GITFLOW_WORKFLOWS_CLEANUP_AGE_THRESHOLD_DAYS", "soon")
+	err := ApplyEnvOverrides(Default())
+	if err == nil || !strings.Contains(err.Error(), "GITFLOW_WORKFLOWS_CLEANUP_AGE_THRESHOLD_DAYS") {
+		t.Fatalf("expected error naming the variable, got %v", err)
+	}
+
+	t.Setenv("GITFLOW_WORKFLOWS_CLEANUP_AGE_THRESHOLD_DAYS", "3")
+	t.Setenv("GITFLOW_WORKFLOWS_SYNC_STRATEGY", "squash")
+	_, err = LoadFromDir(t.TempDir())
+	if err == nil || !strings.HasPrefix(err.Error(), "GITFLOW_WORKFLOWS_SYNC_STRATEGY: workflows.sync.strategy") {
+		t.Fatalf("expected validation error naming the variable, got %v", err)
+	}
+	if strings.Contains(err.Error(), "GITFLOW_WORKFLOWS_CLEANUP_AGE_THRESHOLD_DAYS") {
+		t.Fatalf("expected only the failing variable to be named, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// field is a leaf of Config addressed by its dotted yaml key.
+type field struct {
+	Key   string
+	Value reflect.Value
+}
+
+// fields lists every leaf setting of cfg in declaration order, such as
+// workflows.sync.strategy. Nested structs are walked; lists are leaves.
+func fields(cfg *Config) []field {
+	var out []field
+	walkFields(reflect.ValueOf(cfg).Elem(), "", &out)
+	return out
+}
+
+func walkFields(v reflect.Value, prefix string, out *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := yamlName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			walkFields(fv, name, out)
+			continue
+		}
+		*out = append(*out, field{Key: name, Value: fv})
+	}
+}
+
+// yamlName returns the yaml key of a struct field, or "" when it is skipped.
+func yamlName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	return name
+}
@@ -9,28 +9,6 @@ import (
 	"strings"
 )
 
-func findConfig(startDir string) (string, error) {
-	if p, ok := findConfigInDir(startDir); ok {
-		return p, nil
-	}
-
-	gitRoot, err := gitTopLevel(startDir)
-	if err == nil {
-		if p, ok := findConfigInDir(gitRoot); ok {
-			return p, nil
-		}
-	}
-
-	home, err := os.UserHomeDir()
-	if err == nil {
-		if p, ok := findConfigInDir(home); ok {
-			return p, nil
-		}
-	}
-
-	return "", fmt.Errorf("no config file found")
-}
-
 func findConfigInDir(dir string) (string, bool) {
 	if p := filepath.Join(dir, ".gitflow.yml"); fileExists(p) {
 		return p, true
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config layers, from lowest to highest precedence.
+const (
+	LayerDefault = "default"
+	LayerSystem  = "system"
+	LayerUser    = "user"
+	LayerRepo    = "repo"
+	LayerLocal   = "local"
+	LayerEnv     = "env"
+	LayerFlag    = "flag"
+)
+
+// systemConfigDir holds the machine-wide config.yml.
+var systemConfigDir = "/etc/gitflow"
+
+// Layer is a config file merged into a LoadResult.
+type Layer struct {
+	Name string
+	Path string
+}
+
+// Origin records the layer that set a config value. Source is the file,
+// environment variable or flag it came from.
+type Origin struct {
+	Layer  string
+	Source string
+}
+
+// String formats the origin as "repo /path/.gitflow.yml" or "default".
+func (o Origin) String() string {
+	if o.Source == "" {
+		return o.Layer
+	}
+	return o.Layer + " " + o.Source
+}
+
+// configLayers lists the config files that exist for startDir, from lowest
+// to highest precedence. A file is used by one layer only.
+func configLayers(startDir string) []Layer {
+	var layers []Layer
+	seen := make(map[string]bool)
+	add := func(name, path string) {
+		if path == "" || seen[path] {
+			return
+		}
+		seen[path] = true
+		layers = append(layers, Layer{Name: name, Path: path})
+	}
+
+	if p, ok := findNamedConfig(systemConfigDir, "config"); ok {
+		add(LayerSystem, p)
+	}
+	add(LayerUser, userConfigPath())
+
+	dirs := []string{startDir}
+	if root, err := gitTopLevel(startDir); err == nil && root != startDir {
+		dirs = append(dirs, root)
+	}
+	for _, dir := range dirs {
+		if p, ok := findConfigInDir(dir); ok && !seen[p] {
+			add(LayerRepo, p)
+			break
+		}
+	}
+	for _, dir := range dirs {
+		if p, ok := findNamedConfig(dir, ".gitflow.local"); ok {
+			add(LayerLocal, p)
+			break
+		}
+	}
+	return layers
+}
+
+// userConfigPath returns $XDG_CONFIG_HOME/gitflow/config.yml, falling back to
+// ~/.config and then to the older ~/.gitflow.yml. It is empty when none exist.
+func userConfigPath() string {
+	home, _ := os.UserHomeDir()
+
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if xdg == "" && home != "" {
+		xdg = filepath.Join(home, ".config")
+	}
+	if xdg != "" {
+		if p, ok := findNamedConfig(filepath.Join(xdg, "gitflow"), "config"); ok {
+			return p
+		}
+	}
+	if home != "" {
+		if p, ok := findConfigInDir(home); ok {
+			return p
+		}
+	}
+	return ""
+}
+
+func findNamedConfig(dir, name string) (string, bool) {
+	for _, ext := range []string{".yml", ".yaml"} {
+		if p := filepath.Join(dir, name+ext); fileExists(p) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// mergeFile decodes a config file over cfg, so only the keys it sets change,
+// and records them in origins.
+func mergeFile(cfg *Config, layer Layer, origins map[string]Origin) error {
+	data, err := os.ReadFile(layer.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read config at %s: %w", layer.Path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse yaml at %s: %w", layer.Path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	if err := doc.Decode(cfg); err != nil {
+		return fmt.Errorf("failed to parse yaml at %s: %w", layer.Path, err)
+	}
+
+	origin := Origin{Layer: layer.Name, Source: layer.Path}
+	walkLeaves(doc.Content[0], "", func(key string, _ *yaml.Node, _ *yaml.Node) {
+		origins[key] = origin
+	})
+	return nil
+}
+
+// walkLeaves calls fn for every scalar or list value under a mapping node
+// with its dotted key, the key node and the value node.
+func walkLeaves(node *yaml.Node, prefix string, fn func(key string, k *yaml.Node, v *yaml.Node)) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		key := k.Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if v.Kind == yaml.MappingNode {
+			walkLeaves(v, key, fn)
+			continue
+		}
+		fn(key, k, v)
+	}
+}
+
+// MarshalWithOrigins renders cfg as YAML with each value followed by a
+// comment naming the layer it came from.
+func MarshalWithOrigins(cfg *Config, origins map[string]Origin) ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	walkLeaves(&doc, "", func(key string, k *yaml.Node, v *yaml.Node) {
+		origin, ok := origins[key]
+		if !ok {
+			origin = Origin{Layer: LayerDefault}
+		}
+		// Block lists take their comment on the key line.
+		if v.Kind == yaml.SequenceNode && len(v.Content) > 0 {
+			k.LineComment = origin.String()
+			return
+		}
+		v.LineComment = origin.String()
+	})
+	return yaml.Marshal(&doc)
+}
+
+// SetOrigin records that a key was overridden outside the config files, by
+// an environment variable or a flag.
+func (r *LoadResult) SetOrigin(key string, origin Origin) {
+	if r.Origins == nil {
+		r.Origins = make(map[string]Origin)
+	}
+	r.Origins[key] = origin
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BranchMatcher matches branch names against exact names, globs and regular
+// expressions.
+//
+// A pattern containing any of ( ) | ^ $ + \ { } is a regular expression that
+// must match the whole name. Otherwise * matches within one path segment,
+// ** matches across segments, ? matches one character and [...] a class.
+// Anything else is an exact name.
+type BranchMatcher struct {
+	patterns []branchPattern
+}
+
+type branchPattern struct {
+	raw   string
+	exact string
+	re    *regexp.Regexp
+}
+
+// NewBranchMatcher compiles the given patterns, skipping blank entries.
+func NewBranchMatcher(patterns ...string) (*BranchMatcher, error) {
+	m := &BranchMatcher{}
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		bp, err := compileBranchPattern(p)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns = append(m.patterns, bp)
+	}
+	return m, nil
+}
+
+// Match returns the first pattern matching the branch name.
+func (m *BranchMatcher) Match(name string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	for _, p := range m.patterns {
+		if p.re != nil {
+			if p.re.MatchString(name) {
+				return p.raw, true
+			}
+			continue
+		}
+		if p.exact == name {
+			return p.raw, true
+		}
+	}
+	return "", false
+}
+
+// ProtectedMatcher compiles branches.protected.
+func (c *Config) ProtectedMatcher() (*BranchMatcher, error) {
+	return NewBranchMatcher(c.Branches.Protected...)
+}
+
+func compileBranchPattern(p string) (branchPattern, error) {
+	if strings.ContainsAny(p, `()|^$+\{}`) {
+		re, err := regexp.Compile(`^(?:` + p + `)$`)
+		if err != nil {
+			return branchPattern{}, fmt.Errorf("invalid branch pattern %q: %w", p, err)
+		}
+		return branchPattern{raw: p, re: re}, nil
+	}
+	if strings.ContainsAny(p, "*?[") {
+		re, err := regexp.Compile(globToRegexp(p))
+		if err != nil {
+			return branchPattern{}, fmt.Errorf("invalid branch pattern %q: %w", p, err)
+		}
+		return branchPattern{raw: p, re: re}, nil
+	}
+	return branchPattern{raw: p, exact: p}, nil
+}
+
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		ch := glob[i]
+		switch ch {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(ch)))
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
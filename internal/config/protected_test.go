@@ -0,0 +1,38 @@
+package config
+
+import "testing"
+
+func TestBranchMatcherGlobRegexAndExact(t *testing.T) {
+	m, err := NewBranchMatcher("main", "release/*", "support/**", "env/(prod|staging)", "hotfix/v?")
+	if err != nil {
+		t.Fatalf("NewBranchMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		"main":            true,
+		"mainline":        false,
+		"release/1.0":     true,
+		"release/1.0/rc1": false,
+		"support/2.x":     true,
+		"support/2.x/fix": true,
+		"env/prod":        true,
+		"env/staging":     true,
+		"env/dev":         false,
+		"env/production":  false,
+		"hotfix/v1":       true,
+		"feature/release": false,
+	}
+	for name, want := range cases {
+		if _, got := m.Match(name); got != want {
+			t.Fatalf("Match(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestValidateRejectsBadProtectedPattern(t *testing.T) {
+	cfg := Default()
+	cfg.Branches.Protected = []string{"env/(prod"}
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected invalid regex to be rejected")
+	}
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaURL is the JSON Schema dialect Schema declares.
+const SchemaURL = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaEnums lists the allowed values of keys that take one of a fixed set.
+var schemaEnums = map[string][]string{
+	"provider.type":           {"github", "gitlab"},
+	"workflows.sync.strategy": {"rebase", "merge"},
+	"release.default_bump":    {"major", "minor", "patch"},
+}
+
+// schemaMinimums lists integer keys that must not go below a bound.
+var schemaMinimums = map[string]int{
+	"branches.max_length":                  0,
+	"workflows.cleanup.age_threshold_days": 0,
+}
+
+// Schema returns a JSON Schema for .gitflow.yml generated from Config, for
+// editor completion and validation.
+func Schema() map[string]any {
+	s := schemaFor(reflect.TypeOf(Config{}), "")
+	s["$schema"] = SchemaURL
+	s["title"] = "gitflow configuration"
+	return s
+}
+
+func schemaFor(t reflect.Type, key string) map[string]any {
+	switch t.Kind() {
+	case reflect.Struct:
+		props := make(map[string]any)
+		for i := 0; i < t.NumField(); i++ {
+			name := yamlName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			child := name
+			if key != "" {
+				child = key + "." + name
+			}
+			props[name] = schemaFor(t.Field(i).Type, child)
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           props,
+			"additionalProperties": false,
+		}
+	case reflect.Slice:
+		return map[string]any{
+			"type":  "array",
+			"items": schemaFor(t.Elem(), key),
+		}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int:
+		s := map[string]any{"type": "integer"}
+		if min, ok := schemaMinimums[key]; ok {
+			s["minimum"] = min
+		}
+		return s
+	default:
+		s := map[string]any{"type": "string"}
+		if values, ok := schemaEnums[key]; ok {
+			s["enum"] = values
+		}
+		return s
+	}
+}
+
+// schemaErrors checks cfg against the enums and minimums the schema declares.
+func schemaErrors(cfg *Config) []string {
+	return append(enumErrors(cfg), minimumErrors(cfg)...)
+}
+
+// enumErrors checks the keys in schemaEnums, allowing empty values, which
+// the defaults fill in.
+func enumErrors(cfg *Config) []string {
+	var errs []string
+	for _, f := range fields(cfg) {
+		values, ok := schemaEnums[f.Key]
+		if !ok || f.Value.String() == "" {
+			continue
+		}
+		if !containsString(values, f.Value.String()) {
+			errs = append(errs, fmt.Sprintf("%s must be %s", f.Key, joinChoices(values)))
+		}
+	}
+	return errs
+}
+
+// minimumErrors checks the integer keys in schemaMinimums.
+func minimumErrors(cfg *Config) []string {
+	var errs []string
+	for _, f := range fields(cfg) {
+		min, ok := schemaMinimums[f.Key]
+		if ok && f.Value.Int() < int64(min) {
+			errs = append(errs, fmt.Sprintf("%s must be >= %d", f.Key, min))
+		}
+	}
+	return errs
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+// joinChoices formats values as "a, b or c".
+func joinChoices(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+}
+
+// KeyError reports a key in a config file that gitflow does not know.
+type KeyError struct {
+	Path    string
+	Line    int
+	Column  int
+	Key     string
+	Section string
+	// Suggestion is a known key that differs only in case.
+	Suggestion string
+}
+
+func (e KeyError) Error() string {
+	msg := fmt.Sprintf("%s:%d:%d: unknown key %q", e.Path, e.Line, e.Column, e.Key)
+	if e.Section != "" {
+		msg += " in " + e.Section
+	}
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %s?)", e.Suggestion)
+	}
+	return msg
+}
+
+// CheckKeys reports every key in the config file at path that does not map
+// to a Config field, with its line and column.
+func CheckKeys(path string) ([]KeyError, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config at %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml at %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	var errs []KeyError
+	checkNodeKeys(doc.Content[0], reflect.TypeOf(Config{}), "", path, &errs)
+	return errs, nil
+}
+
+func checkNodeKeys(node *yaml.Node, t reflect.Type, section, path string, errs *[]KeyError) {
+	switch t.Kind() {
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, item := range node.Content {
+			checkNodeKeys(item, t.Elem(), section, path, errs)
+		}
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		known := make(map[string]reflect.Type, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			if name := yamlName(t.Field(i)); name != "" {
+				known[name] = t.Field(i).Type
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			child := k.Value
+			if section != "" {
+				child = section + "." + k.Value
+			}
+			if ft, ok := known[k.Value]; ok {
+				checkNodeKeys(v, ft, child, path, errs)
+				continue
+			}
+			e := KeyError{Path: path, Line: k.Line, Column: k.Column, Key: k.Value, Section: section}
+			for name := range known {
+				if strings.EqualFold(name, k.Value) {
+					e.Suggestion = name
+				}
+			}
+			*errs = append(*errs, e)
+		}
+	}
+}
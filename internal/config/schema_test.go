@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSchemaCoversConfig(t *testing.T) {
+	s := Schema()
+	if s["$schema"] != SchemaURL || s["additionalProperties"] != false {
+		t.Fatalf("unexpected root schema: %v", s)
+	}
+
+	props := s["properties"].(map[string]any)
+	workflows := props["workflows"].(map[string]any)["properties"].(map[string]any)
+	strategy := workflows["sync"].(map[string]any)["properties"].(map[string]any)["strategy"].(map[string]any)
+	if enum := strategy["enum"].([]string); len(enum) != 2 || enum[0] != "rebase" {
+		t.Fatalf("unexpected strategy schema: %v", strategy)
+	}
+
+	branches := props["branches"].(map[string]any)["properties"].(map[string]any)
+	types := branches["types"].(map[string]any)
+	item := types["items"].(map[string]any)
+	if types["type"] != "array" || item["type"] != "object" || item["properties"].(map[string]any)["template"] == nil {
+		t.Fatalf("unexpected branch types schema: %v", types)
+	}
+	if branches["max_length"].(map[string]any)["minimum"] != 0 {
+		t.Fatalf("expected max_length minimum")
+	}
+}
+
+func TestCheckKeysReportsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitflow.yml")
+	data := "workflows:\n  start:\n    auto_pusH: true\nbranches:\n  types:\n    - name: spike\n      base: develop\nextra: 1\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	errs, err := CheckKeys(path)
+	if err != nil {
+		t.Fatalf("CheckKeys: %v", err)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 unknown keys, got %v", errs)
+	}
+	want := []string{
+		path + `:3:5: unknown key "auto_pusH" in workflows.start (did you mean auto_push?)`,
+		path + `:7:7: unknown key "base" in branches.types`,
+		path + `:8:1: unknown key "extra"`,
+	}
+	for i, e := range errs {
+		if e.Error() != want[i] {
+			t.Fatalf("error %d: got %q want %q", i, e.Error(), want[i])
+		}
+	}
+}
+
+func TestValidateStrictUsesSchemaEnums(t *testing.T) {
+	cfg := Default()
+	cfg.Provider.Type = "bitbucket"
+	cfg.Release.DefaultBump = "huge"
+	err := ValidateStrict(cfg)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	for _, msg := range []string{"provider.type must be github or gitlab", "release.default_bump must be major, minor or patch"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Fatalf("expected %q in %v", msg, err)
+		}
+	}
+}
+
+func TestValidateUsesSchemaEnumsAndMinimums(t *testing.T) {
+	cfg := Default()
+	cfg.Release.DefaultBump = "huge"
+	cfg.Workflows.Cleanup.AgeThresholdDays = -1
+	cfg.Branches.MaxLength = -5
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	for _, msg := range []string{
+		"release.default_bump must be major, minor or patch",
+		"workflows.cleanup.age_threshold_days must be >= 0",
+		"branches.max_length must be >= 0",
+	} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Fatalf("expected %q in %v", msg, err)
+		}
+	}
+}
@@ -18,17 +18,18 @@ func ValidateStrict(cfg *Config) error {
 	if cfg.Workflows.Start.BaseBranch == "" {
 		errs = append(errs, "workflows.start.base_branch is required")
 	}
-	if cfg.Workflows.Sync.Strategy != "" && cfg.Workflows.Sync.Strategy != "rebase" && cfg.Workflows.Sync.Strategy != "merge" {
-		errs = append(errs, "workflows.sync.strategy must be rebase or merge")
+	errs = append(errs, schemaErrors(cfg)...)
+	if _, err := NewBranchMatcher(cfg.Branches.Protected...); err != nil {
+		errs = append(errs, "branches.protected: "+err.Error())
 	}
-	if cfg.Workflows.Cleanup.AgeThresholdDays < 0 {
-		errs = append(errs, "workflows.cleanup.age_threshold_days must be >= 0")
+	if err := validateBranchNaming(cfg.Branches); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if _, err := NewBranchMatcher(cfg.Workflows.Cleanup.ProtectedBranches...); err != nil {
+		errs = append(errs, "workflows.cleanup.protected_branches: "+err.Error())
 	}
 
 	if cfg.Provider.Type != "" {
-		if cfg.Provider.Type != "github" && cfg.Provider.Type != "gitlab" {
-			errs = append(errs, "provider.type must be github or gitlab")
-		}
 		if cfg.Provider.TokenEnv == "" {
 			errs = append(errs, "provider.token_env is required when provider is enabled")
 		}
@@ -51,3 +52,26 @@ func ValidateStrict(cfg *Config) error {
 	}
 	return nil
 }
+
+// ValidateResult reports unknown keys in every merged config file, with
+// their line and column, followed by the ValidateStrict errors.
+func ValidateResult(res *LoadResult) error {
+	var errs []string
+	for _, layer := range res.Layers {
+		keyErrs, err := CheckKeys(layer.Path)
+		if err != nil {
+			return err
+		}
+		for _, e := range keyErrs {
+			errs = append(errs, e.Error())
+		}
+	}
+	if err := ValidateStrict(res.Config); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"strings"
+	"time"
+)
+
+// BranchMeta is gitflow's metadata for a branch, stored in the branch's
+// description config so git keeps it across renames and drops it on delete.
+//
+// The description comes first as free text, followed by trailers:
+//
+//	Add login form
+//
+//	Issue: PROJ-42
+//	Parent: main
+//	Created: 2026-01-02T15:04:05Z
+type BranchMeta struct {
+	Description string
+	Issue       string
+	Parent      string
+	Created     time.Time
+}
+
+const (
+	metaIssue   = "Issue"
+	metaParent  = "Parent"
+	metaCreated = "Created"
+)
+
+// Empty reports whether no metadata is set.
+func (m BranchMeta) Empty() bool {
+	return m.Description == "" && m.Issue == "" && m.Parent == "" && m.Created.IsZero()
+}
+
+// Title returns the first line of the description.
+func (m BranchMeta) Title() string {
+	title, _, _ := strings.Cut(m.Description, "\n")
+	return strings.TrimSpace(title)
+}
+
+// Body returns the description after its first line.
+func (m BranchMeta) Body() string {
+	_, body, _ := strings.Cut(m.Description, "\n")
+	return strings.TrimSpace(body)
+}
+
+// ParseBranchMeta reads metadata from a branch description.
+func ParseBranchMeta(raw string) BranchMeta {
+	var m BranchMeta
+	lines := strings.Split(strings.TrimSpace(raw), "\n")
+
+	// Trailers are the trailing block of known "Key: value" lines.
+	end := len(lines)
+	for end > 0 {
+		key, value, ok := strings.Cut(lines[end-1], ":")
+		if !ok || !m.setTrailer(strings.TrimSpace(key), strings.TrimSpace(value)) {
+			break
+		}
+		end--
+	}
+
+	m.Description = strings.TrimSpace(strings.Join(lines[:end], "\n"))
+	return m
+}
+
+func (m *BranchMeta) setTrailer(key, value string) bool {
+	switch key {
+	case metaIssue:
+		m.Issue = value
+	case metaParent:
+		m.Parent = value
+	case metaCreated:
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return false
+		}
+		m.Created = t
+	default:
+		return false
+	}
+	return true
+}
+
+// String formats metadata as a branch description.
+func (m BranchMeta) String() string {
+	var trailers []string
+	if m.Issue != "" {
+		trailers = append(trailers, metaIssue+": "+m.Issue)
+	}
+	if m.Parent != "" {
+		trailers = append(trailers, metaParent+": "+m.Parent)
+	}
+	if !m.Created.IsZero() {
+		trailers = append(trailers, metaCreated+": "+m.Created.UTC().Format(time.RFC3339))
+	}
+
+	desc := strings.TrimSpace(m.Description)
+	if len(trailers) == 0 {
+		return desc
+	}
+	if desc == "" {
+		return strings.Join(trailers, "\n")
+	}
+	return desc + "\n\n" + strings.Join(trailers, "\n")
+}
+
+// BranchMeta returns the metadata stored for a branch.
+func (c *Client) BranchMeta(branch string) (BranchMeta, error) {
+	raw, err := c.ConfigValue("branch." + branch + ".description")
+	if err != nil {
+		return BranchMeta{}, err
+	}
+	return ParseBranchMeta(raw), nil
+}
+
+// SetBranchMeta stores metadata for a branch, removing it when empty.
+func (c *Client) SetBranchMeta(branch string, m BranchMeta) error {
+	key := "branch." + branch + ".description"
+	if m.Empty() {
+		if current, _ := c.ConfigValue(key); current == "" {
+			return nil
+		}
+		_, err := c.Run("config", "--unset", key)
+		return err
+	}
+	_, err := c.Run("config", key, m.String())
+	return err
+}
+
+// AllBranchMeta returns metadata for every branch that has a description.
+func (c *Client) AllBranchMeta() (map[string]BranchMeta, error) {
+	out, err := c.Run("config", "-z", "--get-regexp", `^branch\..*\.description$`)
+	if err != nil {
+		// No matching keys makes git config exit non-zero.
+		return map[string]BranchMeta{}, nil
+	}
+
+	metas := make(map[string]BranchMeta)
+	for _, entry := range strings.Split(out, "\x00") {
+		key, value, _ := strings.Cut(entry, "\n")
+		if !strings.HasPrefix(key, "branch.") || !strings.HasSuffix(key, ".description") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "branch."), ".description")
+		metas[name] = ParseBranchMeta(value)
+	}
+	return metas, nil
+}
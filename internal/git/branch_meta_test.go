@@ -0,0 +1,56 @@
+package git
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBranchMetaRoundTrip(t *testing.T) {
+	created := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	m := BranchMeta{
+		Description: "Add login form\n\nUses the new session API.",
+		Issue:       "PROJ-42",
+		Parent:      "develop",
+		Created:     created,
+	}
+
+	got := ParseBranchMeta(m.String())
+	if got != m {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, m)
+	}
+	if got.Title() != "Add login form" || got.Body() != "Uses the new session API." {
+		t.Fatalf("unexpected title/body %q / %q", got.Title(), got.Body())
+	}
+
+	plain := ParseBranchMeta("Note: written by git branch --edit-description")
+	if plain.Description != "Note: written by git branch --edit-description" || plain.Issue != "" {
+		t.Fatalf("expected unknown trailer to stay in description, got %+v", plain)
+	}
+}
+
+func TestAllBranchMetaReadsEveryBranch(t *testing.T) {
+	dir := setupBranchRepo(t)
+	c, err := NewClient(dir)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if err := c.SetBranchMeta("feature/a.b", BranchMeta{Description: "dotted\nname", Issue: "7"}); err != nil {
+		t.Fatalf("SetBranchMeta: %v", err)
+	}
+
+	metas, err := c.AllBranchMeta()
+	if err != nil {
+		t.Fatalf("AllBranchMeta: %v", err)
+	}
+	if m := metas["feature/a.b"]; m.Description != "dotted\nname" || m.Issue != "7" {
+		t.Fatalf("unexpected metadata %+v", metas)
+	}
+
+	if err := c.SetBranchMeta("feature/a.b", BranchMeta{}); err != nil {
+		t.Fatalf("clear metadata: %v", err)
+	}
+	if m, _ := c.BranchMeta("feature/a.b"); !m.Empty() {
+		t.Fatalf("expected metadata to be cleared, got %+v", m)
+	}
+}
@@ -4,69 +4,144 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gitflow/pkg/types"
 )
 
+// aheadBehindWorkers bounds the rev-list processes run at once when git is
+// too old for the ahead-behind format atom.
+const aheadBehindWorkers = 8
+
 // ListLocalBranches returns local branches with summary metadata.
 func (c *Client) ListLocalBranches(baseBranch string) ([]*types.Branch, error) {
-	current, err := c.CurrentBranch()
+	if strings.TrimSpace(baseBranch) == "" {
+		baseBranch = "main"
+	}
+	return c.listBranches("refs/heads/", baseBranch)
+}
+
+// ListRemoteBranches returns the branches of a remote from its remote-tracking refs.
+// Branch names are returned without the remote prefix. Ahead and behind counts
+// are filled in against baseBranch when it is set.
+func (c *Client) ListRemoteBranches(remote, baseBranch string) ([]*types.Branch, error) {
+	branches, err := c.listBranches("refs/remotes/"+remote+"/", baseBranch)
 	if err != nil {
 		return nil, err
 	}
 
-	if strings.TrimSpace(baseBranch) == "" {
-		baseBranch = "main"
+	out := branches[:0]
+	for _, b := range branches {
+		name := strings.TrimPrefix(b.Name, remote+"/")
+		if name == "HEAD" || name == remote {
+			continue
+		}
+		b.Name = name
+		b.Current = false
+		out = append(out, b)
 	}
+	return out, nil
+}
 
-	format := "%(refname:short)|%(authorname)|%(subject)"
-	out, err := c.Run("for-each-ref", "--format="+format, "refs/heads/")
+// listBranches reads every branch under prefix with a single for-each-ref.
+// Ahead and behind counts come from the ahead-behind atom (git 2.41+); older
+// git rejects the atom, so the counts are computed by a bounded worker pool.
+func (c *Client) listBranches(prefix, base string) ([]*types.Branch, error) {
+	fields := []string{"%(refname:short)", "%(HEAD)", "%(authorname)", "%(committerdate:unix)", "%(subject)"}
+
+	if base == "" {
+		return c.forEachBranch(prefix, fields)
+	}
+	if _, err := c.ResolveCommit(base); err != nil {
+		// Without a base there is nothing to count against.
+		return c.forEachBranch(prefix, fields)
+	}
+
+	branches, err := c.forEachBranch(prefix, append(fields, "%(ahead-behind:"+base+")"))
+	if err == nil {
+		return branches, nil
+	}
+
+	branches, err = c.forEachBranch(prefix, fields)
 	if err != nil {
 		return nil, err
 	}
+	c.fillAheadBehind(branches, base)
+	return branches, nil
+}
 
-	lines := strings.Split(strings.TrimSpace(out), "\n")
-	var branches []*types.Branch
+func (c *Client) forEachBranch(prefix string, fields []string) ([]*types.Branch, error) {
+	out, err := c.Run("for-each-ref", "--format="+strings.Join(fields, "%00"), prefix)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	now := time.Now()
+	var branches []*types.Branch
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Split(line, "\x00")
+		if len(parts) != len(fields) || parts[0] == "" {
 			continue
 		}
 
-		parts := strings.SplitN(line, "|", 3)
-		if len(parts) != 3 {
-			continue
+		b := &types.Branch{
+			Name:          parts[0],
+			Current:       parts[1] == "*",
+			Author:        strings.TrimSpace(parts[2]),
+			LastCommitMsg: strings.TrimSpace(parts[4]),
 		}
+		if sec, err := strconv.ParseInt(parts[3], 10, 64); err == nil {
+			if age := now.Sub(time.Unix(sec, 0)); age > 0 {
+				b.AgeDays = int(age.Hours() / 24)
+			}
+		}
+		if len(parts) > 5 {
+			if counts := strings.Fields(parts[5]); len(counts) == 2 {
+				b.Ahead, _ = strconv.Atoi(counts[0])
+				b.Behind, _ = strconv.Atoi(counts[1])
+			}
+		}
+		branches = append(branches, b)
+	}
+	return branches, nil
+}
 
-		name := strings.TrimSpace(parts[0])
-		author := strings.TrimSpace(parts[1])
-		subject := strings.TrimSpace(parts[2])
-
-		ageDays, _ := c.branchAgeDays(name)
+// fillAheadBehind counts commits against base for each branch, running at
+// most aheadBehindWorkers rev-list processes at a time. Branches whose counts
+// cannot be computed keep zeros.
+func (c *Client) fillAheadBehind(branches []*types.Branch, base string) {
+	jobs := make(chan *types.Branch)
+	var wg sync.WaitGroup
 
-		ahead, behind := 0, 0
-		if name != baseBranch {
-			a, b, err := c.aheadBehind(name, baseBranch)
-			if err == nil {
-				ahead = a
-				behind = b
+	workers := aheadBehindWorkers
+	if len(branches) < workers {
+		workers = len(branches)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				if a, bh, err := c.aheadBehind(b.Name, base); err == nil {
+					b.Ahead, b.Behind = a, bh
+				}
 			}
-		}
+		}()
+	}
 
-		branches = append(branches, &types.Branch{
-			Name:          name,
-			Current:       name == current,
-			LastCommitMsg: subject,
-			Author:        author,
-			AgeDays:       ageDays,
-			Ahead:         ahead,
-			Behind:        behind,
-		})
+	for _, b := range branches {
+		if b.Name != base {
+			jobs <- b
+		}
 	}
+	close(jobs)
+	wg.Wait()
+}
 
-	return branches, nil
+// AheadBehind counts commits on branch not on base, and on base not on branch.
+func (c *Client) AheadBehind(branch string, base string) (ahead int, behind int, err error) {
+	return c.aheadBehind(branch, base)
 }
 
 func (c *Client) aheadBehind(branch string, base string) (ahead int, behind int, err error) {
@@ -92,21 +167,126 @@ func (c *Client) aheadBehind(branch string, base string) (ahead int, behind int,
 	return aheadCount, behindCount, nil
 }
 
-func (c *Client) branchAgeDays(branch string) (int, error) {
-	out, err := c.Run("log", "-1", "--format=%ct", branch)
+// LocalBranchNames returns the short names of all local branches.
+func (c *Client) LocalBranchNames() ([]string, error) {
+	out, err := c.Run("for-each-ref", "--format=%(refname:short)", "refs/heads/")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// CherryEquivalent reports whether every commit on head has a patch-equivalent
+// commit in upstream, which is how rebase merges land.
+func (c *Client) CherryEquivalent(upstream, head string) (bool, error) {
+	out, err := c.Run("cherry", upstream, head)
 	if err != nil {
-		return 0, err
+		return false, err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "+") {
+			return false, nil
+		}
 	}
+	return true, nil
+}
 
-	sec, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+// SquashMerged reports whether the combined changes of branch since its merge
+// base already landed on base as a single commit. It compares the patch id of
+// the branch's combined diff with the patch ids of the commits on base since
+// the merge base, so no objects are written to the repository.
+func (c *Client) SquashMerged(base, branch string) (bool, error) {
+	mergeBase, err := c.MergeBase(base, branch)
 	if err != nil {
-		return 0, err
+		return false, err
+	}
+	diff, err := c.Run("diff", "--no-color", mergeBase, branch)
+	if err != nil || diff == "" {
+		return false, err
 	}
+	want, err := c.patchIDs(diff)
+	if err != nil || len(want) == 0 {
+		return false, err
+	}
+	log, err := c.Run("log", "--no-merges", "--no-color", "-p", "--format=commit %H", mergeBase+".."+base)
+	if err != nil || log == "" {
+		return false, err
+	}
+	have, err := c.patchIDs(log)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range have {
+		if id == want[0] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// patchIDs returns the stable patch id of each patch in the input.
+func (c *Client) patchIDs(patches string) ([]string, error) {
+	out, err := c.RunWithInput(patches+"\n", "patch-id", "--stable")
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, line := range strings.Split(out, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			ids = append(ids, fields[0])
+		}
+	}
+	return ids, nil
+}
+
+// GoneBranches lists local branches whose configured upstream no longer exists.
+func (c *Client) GoneBranches() ([]string, error) {
+	out, err := c.Run("for-each-ref", "--format=%(refname:short)|%(upstream:track)", "refs/heads/")
+	if err != nil {
+		return nil, err
+	}
+	var gone []string
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "|", 2)
+		if len(parts) == 2 && parts[1] == "[gone]" {
+			gone = append(gone, parts[0])
+		}
+	}
+	return gone, nil
+}
+
+// MergedRemoteBranches lists branches of a remote merged into the target.
+// Branch names are returned without the remote prefix.
+func (c *Client) MergedRemoteBranches(remote, target string) ([]string, error) {
+	out, err := c.Run("branch", "-r", "--merged", target)
+	if err != nil {
+		return nil, err
+	}
+	var branches []string
+	for _, line := range strings.Split(out, "\n") {
+		b := strings.TrimSpace(line)
+		if b == "" || strings.Contains(b, " -> ") || !strings.HasPrefix(b, remote+"/") {
+			continue
+		}
+		branches = append(branches, strings.TrimPrefix(b, remote+"/"))
+	}
+	return branches, nil
+}
 
-	commitTime := time.Unix(sec, 0)
-	age := time.Since(commitTime)
-	if age < 0 {
-		return 0, nil
+// DeleteRemoteBranches deletes several branches from the remote in one
+// atomic push, so either all of them are deleted or none are.
+func (c *Client) DeleteRemoteBranches(remote string, branches ...string) error {
+	if len(branches) == 0 {
+		return nil
 	}
-	return int(age.Hours() / 24), nil
+	args := append([]string{"push", "--atomic", remote, "--delete"}, branches...)
+	_, err := c.Run(args...)
+	return err
 }
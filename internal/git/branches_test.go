@@ -1,13 +1,15 @@
 package git
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
-func runGit(t *testing.T, dir string, args ...string) {
+func runGit(t testing.TB, dir string, args ...string) {
 	t.Helper()
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
@@ -71,3 +73,97 @@ func TestListLocalBranches(t *testing.T) {
 		t.Fatalf("expected a current branch")
 	}
 }
+
+func TestListLocalBranchesCountsAgainstBase(t *testing.T) {
+	repo := manyBranchRepo(t, 5)
+
+	c, err := NewClient(repo)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	branches, err := c.ListLocalBranches("main")
+	if err != nil {
+		t.Fatalf("ListLocalBranches: %v", err)
+	}
+	if len(branches) != 6 {
+		t.Fatalf("expected 6 branches, got %d", len(branches))
+	}
+	for _, b := range branches {
+		want := 1
+		if b.Name == "main" {
+			want = 0
+			if !b.Current {
+				t.Fatalf("expected main to be current")
+			}
+		}
+		if b.Ahead != want || b.Behind != 0 {
+			t.Fatalf("%s: ahead/behind = %d/%d, want %d/0", b.Name, b.Ahead, b.Behind, want)
+		}
+		if b.AgeDays < 1000 {
+			t.Fatalf("%s: expected age from the 2023 commit date, got %d days", b.Name, b.AgeDays)
+		}
+	}
+
+	// The worker pool must agree with the for-each-ref counts on any git version.
+	c.fillAheadBehind(branches, "main")
+	for _, b := range branches {
+		if b.Name != "main" && b.Ahead != 1 {
+			t.Fatalf("%s: worker pool ahead = %d, want 1", b.Name, b.Ahead)
+		}
+	}
+}
+
+func BenchmarkListLocalBranches(b *testing.B) {
+	repo := manyBranchRepo(b, 300)
+
+	c, err := NewClient(repo)
+	if err != nil {
+		b.Fatalf("NewClient: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		branches, err := c.ListLocalBranches("main")
+		if err != nil {
+			b.Fatalf("ListLocalBranches: %v", err)
+		}
+		if len(branches) != 301 {
+			b.Fatalf("expected 301 branches, got %d", len(branches))
+		}
+	}
+}
+
+// manyBranchRepo builds a repository with n feature branches, each one commit
+// ahead of main, in a single fast-import run.
+func manyBranchRepo(tb testing.TB, n int) string {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	runGit(tb, dir, "init", "-q")
+
+	var stream strings.Builder
+	commit := func(ref string, mark int, from int, file string) {
+		fmt.Fprintf(&stream, "commit %s\nmark :%d\n", ref, mark)
+		fmt.Fprintf(&stream, "committer Bench <bench@example.com> 1700000000 +0000\n")
+		msg := "add " + file
+		fmt.Fprintf(&stream, "data %d\n%s\n", len(msg), msg)
+		if from > 0 {
+			fmt.Fprintf(&stream, "from :%d\n", from)
+		}
+		fmt.Fprintf(&stream, "M 644 inline %s\ndata 1\nx\n\n", file)
+	}
+	commit("refs/heads/main", 1, 0, "README.md")
+	for i := 0; i < n; i++ {
+		commit(fmt.Sprintf("refs/heads/feature/b%03d", i), i+2, 1, fmt.Sprintf("f%03d.txt", i))
+	}
+
+	cmd := exec.Command("git", "fast-import", "--quiet")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(stream.String())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		tb.Fatalf("fast-import failed: %v output: %s", err, string(out))
+	}
+	runGit(tb, dir, "symbolic-ref", "HEAD", "refs/heads/main")
+	return dir
+}
@@ -3,8 +3,11 @@ package git
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -257,3 +260,234 @@ func (c *Client) BranchExists(branch string) (bool, error) {
 	}
 	return true, nil
 }
+
+// MergeBase returns the best common ancestor of two refs.
+func (c *Client) MergeBase(a, b string) (string, error) {
+	return c.Run("merge-base", a, b)
+}
+
+// CommitFixup creates a fixup commit targeting the given commit.
+func (c *Client) CommitFixup(target string) error {
+	_, err := c.Run("commit", "--fixup="+target)
+	return err
+}
+
+// RebaseAutosquash runs a non-interactive autosquash rebase onto the target.
+func (c *Client) RebaseAutosquash(target string) error {
+	_, err := c.Run("-c", "sequence.editor=:", "rebase", "-i", "--autosquash", target)
+	return err
+}
+
+// RebaseAbort aborts an in-progress rebase.
+func (c *Client) RebaseAbort() error {
+	_, err := c.Run("rebase", "--abort")
+	return err
+}
+
+// GitDir returns the absolute path of the repository git directory.
+func (c *Client) GitDir() (string, error) {
+	return c.Run("rev-parse", "--absolute-git-dir")
+}
+
+// HeadCommit returns the full hash of HEAD.
+func (c *Client) HeadCommit() (string, error) {
+	return c.Run("rev-parse", "HEAD")
+}
+
+// ConflictedFiles lists paths with unresolved merge conflicts.
+func (c *Client) ConflictedFiles() ([]string, error) {
+	out, err := c.Run("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// RebaseInProgress reports whether a rebase is stopped in the repository.
+func (c *Client) RebaseInProgress() (bool, error) {
+	gitDir, err := c.GitDir()
+	if err != nil {
+		return false, err
+	}
+	return pathExists(filepath.Join(gitDir, "rebase-merge")) || pathExists(filepath.Join(gitDir, "rebase-apply")), nil
+}
+
+// MergeInProgress reports whether a merge is waiting to be concluded.
+func (c *Client) MergeInProgress() (bool, error) {
+	gitDir, err := c.GitDir()
+	if err != nil {
+		return false, err
+	}
+	return pathExists(filepath.Join(gitDir, "MERGE_HEAD")), nil
+}
+
+// RebaseContinue resumes a stopped rebase without opening an editor.
+func (c *Client) RebaseContinue() error {
+	_, err := c.Run("-c", "core.editor=true", "rebase", "--continue")
+	return err
+}
+
+// MergeContinue concludes a merge using the prepared message.
+func (c *Client) MergeContinue() error {
+	_, err := c.Run("commit", "--no-edit")
+	return err
+}
+
+// MergeAbort aborts an in-progress merge.
+func (c *Client) MergeAbort() error {
+	_, err := c.Run("merge", "--abort")
+	return err
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ResetKeep moves the current branch to ref, keeping local changes.
+func (c *Client) ResetKeep(ref string) error {
+	_, err := c.Run("reset", "--keep", ref)
+	return err
+}
+
+// FastForwardBranch fast-forwards a local branch that is not checked out
+// from the same-named branch on the remote.
+func (c *Client) FastForwardBranch(remote, branch string) error {
+	_, err := c.Run("fetch", remote, branch+":"+branch)
+	return err
+}
+
+// StashPush stashes tracked and untracked changes and returns the stash commit.
+func (c *Client) StashPush(message string) (string, error) {
+	if _, err := c.Run("stash", "push", "--include-untracked", "-m", message); err != nil {
+		return "", err
+	}
+	return c.Run("rev-parse", "stash@{0}")
+}
+
+// StashPop applies and drops the stash entry with the given commit hash.
+func (c *Client) StashPop(hash string) error {
+	out, err := c.Run("stash", "list", "--format=%H")
+	if err != nil {
+		return err
+	}
+	for i, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == hash {
+			_, err := c.Run("stash", "pop", fmt.Sprintf("stash@{%d}", i))
+			return err
+		}
+	}
+	return fmt.Errorf("stash %s not found", hash)
+}
+
+// FetchPrune updates refs from the remote and drops stale remote-tracking refs.
+func (c *Client) FetchPrune(remote string) error {
+	_, err := c.Run("fetch", "--prune", remote)
+	return err
+}
+
+// ResolveCommit returns the full commit hash a ref points to.
+func (c *Client) ResolveCommit(ref string) (string, error) {
+	return c.Run("rev-parse", "--verify", ref+"^{commit}")
+}
+
+// IsAncestor reports whether ancestor is reachable from descendant.
+func (c *Client) IsAncestor(ancestor, descendant string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, descendant)
+	cmd.Dir = c.repoPath
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("git merge-base --is-ancestor failed: %w", err)
+}
+
+// CreateBranchAt creates a branch pointing at the given commit.
+func (c *Client) CreateBranchAt(branch, commit string) error {
+	_, err := c.Run("branch", branch, commit)
+	return err
+}
+
+// PushCommit creates or updates a remote branch to point at the given commit.
+func (c *Client) PushCommit(remote, commit, branch string) error {
+	_, err := c.Run("push", remote, commit+":refs/heads/"+branch)
+	return err
+}
+
+// ConfigValue returns a git config value, or an empty string when unset.
+func (c *Client) ConfigValue(key string) (string, error) {
+	out, err := c.Run("config", "--get", key)
+	if err != nil {
+		return "", nil
+	}
+	return out, nil
+}
+
+// SetConfigValue sets a git config value in the repository config.
+func (c *Client) SetConfigValue(key, value string) error {
+	_, err := c.Run("config", key, value)
+	return err
+}
+
+// CheckBranchName reports whether name is a valid branch name.
+func (c *Client) CheckBranchName(name string) error {
+	if _, err := c.Run("check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	return nil
+}
+
+// RenameBranch renames a local branch, moving its config and reflog.
+func (c *Client) RenameBranch(from, to string) error {
+	_, err := c.Run("branch", "-m", from, to)
+	return err
+}
+
+// SetUpstream sets the upstream of a local branch to remote/branch.
+func (c *Client) SetUpstream(branch, remote, upstream string) error {
+	_, err := c.Run("branch", "--set-upstream-to="+remote+"/"+upstream, branch)
+	return err
+}
+
+// PushRename pushes branch to as a new remote branch and deletes from on the
+// remote in a single atomic push.
+func (c *Client) PushRename(remote, from, to string) error {
+	_, err := c.Run("push", "--atomic", remote, "refs/heads/"+to+":refs/heads/"+to, ":refs/heads/"+from)
+	return err
+}
+
+// HooksDir returns the directory git runs hooks from, honouring core.hooksPath.
+func (c *Client) HooksDir() (string, error) {
+	dir, err := c.Run("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(c.repoPath, dir)
+	}
+	return dir, nil
+}
+
+// Version returns the installed git version, such as 2.43.0.
+func (c *Client) Version() (string, error) {
+	out, err := c.Run("version")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(out)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return "", fmt.Errorf("unexpected git version output: %q", out)
+	}
+	return fields[2], nil
+}
@@ -111,3 +111,16 @@ func (c *Client) CreateAnnotatedTag(tag, message string) error {
 	}
 	return nil
 }
+
+// RecentSubjects returns the subjects of up to n commits reachable from
+// HEAD, newest first, skipping merges.
+func (c *Client) RecentSubjects(n int) ([]string, error) {
+	out, err := c.Run("log", "--no-merges", fmt.Sprintf("-n%d", n), "--pretty=format:%s")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RemoteInfo is the host and repository path parsed from a remote URL.
+type RemoteInfo struct {
+	Host string
+	// Owner is the user, organisation or group path, such as acme or
+	// group/subgroup on GitLab.
+	Owner string
+	Repo  string
+}
+
+// RemoteURL returns the fetch URL of a remote.
+func (c *Client) RemoteURL(remote string) (string, error) {
+	return c.Run("remote", "get-url", remote)
+}
+
+// AddRemote adds a remote with the given URL.
+func (c *Client) AddRemote(remote, url string) error {
+	_, err := c.Run("remote", "add", remote, url)
+	return err
+}
+
+// ParseRemoteURL reads the host, owner and repository from https, ssh and
+// scp-style remote URLs such as git@github.com:acme/repo.git.
+func ParseRemoteURL(raw string) (RemoteInfo, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return RemoteInfo{}, false
+	}
+
+	var host, path string
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return RemoteInfo{}, false
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: [user@]host:path
+		at := strings.LastIndex(raw, "@")
+		hostPath := raw[at+1:]
+		h, p, ok := strings.Cut(hostPath, ":")
+		if !ok {
+			return RemoteInfo{}, false
+		}
+		host, path = h, p
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	i := strings.LastIndex(path, "/")
+	if host == "" || i <= 0 || i == len(path)-1 {
+		return RemoteInfo{}, false
+	}
+	return RemoteInfo{Host: host, Owner: path[:i], Repo: path[i+1:]}, true
+}
+
+// RemoteHead returns the branch a remote's HEAD points at, such as main for
+// refs/remotes/origin/HEAD -> origin/main.
+func (c *Client) RemoteHead(remote string) (string, error) {
+	ref, err := c.Run("symbolic-ref", "--short", "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(ref, remote+"/"), nil
+}
@@ -0,0 +1,138 @@
+package git
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TreeStatus is the branch and working tree state reported by git status.
+type TreeStatus struct {
+	Branch   string
+	Detached bool
+	// Head is the HEAD commit, empty on an unborn branch.
+	Head string
+
+	Upstream     string
+	UpstreamGone bool
+	Ahead        int
+	Behind       int
+
+	Staged     int
+	Unstaged   int
+	Untracked  int
+	Conflicted int
+}
+
+// Dirty reports whether the working tree or index has changes.
+func (s *TreeStatus) Dirty() bool {
+	return s.Staged+s.Unstaged+s.Untracked+s.Conflicted > 0
+}
+
+// TreeStatus reads branch, upstream and change counts from a single
+// git status --porcelain=v2 call.
+func (c *Client) TreeStatus() (*TreeStatus, error) {
+	out, err := c.Run("status", "--porcelain=v2", "--branch", "--untracked-files=normal")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &TreeStatus{}
+	hasAB := false
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.oid "):
+			if oid := strings.TrimPrefix(line, "# branch.oid "); oid != "(initial)" {
+				s.Head = oid
+			}
+		case strings.HasPrefix(line, "# branch.head "):
+			s.Branch = strings.TrimPrefix(line, "# branch.head ")
+			if s.Branch == "(detached)" {
+				s.Branch = ""
+				s.Detached = true
+			}
+		case strings.HasPrefix(line, "# branch.upstream "):
+			s.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			hasAB = true
+			for _, f := range strings.Fields(strings.TrimPrefix(line, "# branch.ab ")) {
+				n, _ := strconv.Atoi(f[1:])
+				if f[0] == '+' {
+					s.Ahead = n
+				} else {
+					s.Behind = n
+				}
+			}
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "):
+			if len(line) < 4 {
+				continue
+			}
+			if line[2] != '.' {
+				s.Staged++
+			}
+			if line[3] != '.' {
+				s.Unstaged++
+			}
+		case strings.HasPrefix(line, "u "):
+			s.Conflicted++
+		case strings.HasPrefix(line, "? "):
+			s.Untracked++
+		}
+	}
+	// git omits the ahead/behind line when the upstream ref is missing.
+	s.UpstreamGone = s.Upstream != "" && !hasAB
+	return s, nil
+}
+
+// StashCount returns the number of stash entries.
+func (c *Client) StashCount() (int, error) {
+	if ok, err := c.BranchExists("refs/stash"); err != nil || !ok {
+		return 0, err
+	}
+	out, err := c.Run("rev-list", "--walk-reflogs", "--count", "refs/stash")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(out)
+}
+
+// InProgressOperation names the operation stopped in the repository: rebase,
+// am, merge, cherry-pick, revert or bisect. It is empty when there is none.
+func (c *Client) InProgressOperation() (string, error) {
+	gitDir, err := c.GitDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case pathExists(filepath.Join(gitDir, "rebase-merge")):
+		return "rebase", nil
+	case pathExists(filepath.Join(gitDir, "rebase-apply", "applying")):
+		return "am", nil
+	case pathExists(filepath.Join(gitDir, "rebase-apply")):
+		return "rebase", nil
+	case pathExists(filepath.Join(gitDir, "MERGE_HEAD")):
+		return "merge", nil
+	case pathExists(filepath.Join(gitDir, "CHERRY_PICK_HEAD")):
+		return "cherry-pick", nil
+	case pathExists(filepath.Join(gitDir, "REVERT_HEAD")):
+		return "revert", nil
+	case pathExists(filepath.Join(gitDir, "BISECT_LOG")):
+		return "bisect", nil
+	}
+	return "", nil
+}
+
+// CountCommits counts the commits reachable from head but not from base.
+// An empty base counts every commit reachable from head.
+func (c *Client) CountCommits(base, head string) (int, error) {
+	rng := head
+	if base != "" {
+		rng = base + ".." + head
+	}
+	out, err := c.Run("rev-list", "--count", rng)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(out)
+}
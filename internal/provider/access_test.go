@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGitHubAccessReadsScopesAndRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/repo" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("X-OAuth-Scopes", "read:org, public_repo")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4990")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.Header().Set("GitHub-Authentication-Token-Expiration", "2030-01-02 03:04:05 UTC")
+		w.Write([]byte(`{"private":false,"permissions":{"push":true,"pull":true}}`))
+	}))
+	defer server.Close()
+
+	g, err := NewGitHub(ProviderConfig{Type: "github", BaseURL: server.URL, Token: "t", Owner: "acme", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("NewGitHub: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	report, err := g.Access(ctx)
+	if err != nil {
+		t.Fatalf("Access: %v", err)
+	}
+	if !reflect.DeepEqual(report.Scopes, []string{"read:org", "public_repo"}) {
+		t.Fatalf("unexpected scopes: %v", report.Scopes)
+	}
+	if report.Role != "push" || !report.CanCreatePR || !report.CanCreateRelease {
+		t.Fatalf("expected push access, got %+v", report)
+	}
+	if report.RateLimit.Limit != 5000 || report.RateLimit.Remaining != 4990 || report.RateLimit.Reset.Unix() != 1700000000 {
+		t.Fatalf("unexpected rate limit: %+v", report.RateLimit)
+	}
+	if report.ExpiresAt.Year() != 2030 {
+		t.Fatalf("unexpected expiry: %v", report.ExpiresAt)
+	}
+}
+
+func TestGitHubAccessPrivateRepoNeedsRepoScope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "public_repo")
+		w.Write([]byte(`{"private":true,"permissions":{"admin":true,"push":true,"pull":true}}`))
+	}))
+	defer server.Close()
+
+	g, err := NewGitHub(ProviderConfig{Type: "github", BaseURL: server.URL, Token: "t", Owner: "acme", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("NewGitHub: %v", err)
+	}
+	report, err := g.Access(context.Background())
+	if err != nil {
+		t.Fatalf("Access: %v", err)
+	}
+	if report.Role != "admin" || report.CanCreatePR || report.CanCreateRelease {
+		t.Fatalf("expected admin without write scope, got %+v", report)
+	}
+}
+
+func TestGitLabAccessReadsTokenScopes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/acme%2Frepo":
+			w.Header().Set("RateLimit-Limit", "2000")
+			w.Header().Set("RateLimit-Remaining", "100")
+			w.Write([]byte(`{"permissions":{"project_access":{"access_level":20},"group_access":{"access_level":30}}}`))
+		case "/personal_access_tokens/self":
+			if r.Header.Get("PRIVATE-TOKEN") != "t" {
+				t.Fatalf("missing token header")
+			}
+			w.Write([]byte(`{"scopes":["read_api","write_repository"],"expires_at":"2030-06-01"}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	g, err := NewGitLab(ProviderConfig{Type: "gitlab", BaseURL: server.URL, Token: "t", Owner: "acme", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("NewGitLab: %v", err)
+	}
+	report, err := g.Access(context.Background())
+	if err != nil {
+		t.Fatalf("Access: %v", err)
+	}
+	if report.Role != "developer" {
+		t.Fatalf("expected developer role, got %s", report.Role)
+	}
+	if report.CanCreatePR || report.CanCreateRelease {
+		t.Fatalf("expected read_api token to be refused writes, got %+v", report)
+	}
+	if report.RateLimit.Limit != 2000 || report.RateLimit.Remaining != 100 {
+		t.Fatalf("unexpected rate limit: %+v", report.RateLimit)
+	}
+	if report.ExpiresAt.Format("2006-01-02") != "2030-06-01" {
+		t.Fatalf("unexpected expiry: %v", report.ExpiresAt)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"gitflow/pkg/types"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -61,10 +62,19 @@ func (g *GitHub) GetDefaultBranch(ctx context.Context) (string, error) {
 	return repo.DefaultBranch, nil
 }
 
-// do executes a GitHub API request and optionally decodes JSON.
+// do executes a GitHub API request against the repository and optionally
+// decodes JSON.
 func (g *GitHub) do(ctx context.Context, method string, path string, body any, out any) (*http.Response, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s%s", strings.TrimRight(g.baseURL, "/"), g.owner, g.repo, path)
+	return g.send(ctx, method, g.repoURL(path), body, out)
+}
+
+func (g *GitHub) repoURL(path string) string {
+	return fmt.Sprintf("%s/repos/%s/%s%s", strings.TrimRight(g.baseURL, "/"), g.owner, g.repo, path)
+}
 
+// send executes a GitHub API request against an absolute URL, such as the
+// next page from a Link header.
+func (g *GitHub) send(ctx context.Context, method string, url string, body any, out any) (*http.Response, error) {
 	var r io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -131,6 +141,7 @@ func (g *GitHub) CreatePR(ctx context.Context, opts CreatePROptions) (*types.Pul
 		Draft   bool   `json:"draft"`
 		Head    struct {
 			Ref string `json:"ref"`
+			SHA string `json:"sha"`
 		} `json:"head"`
 		Base struct {
 			Ref string `json:"ref"`
@@ -148,6 +159,7 @@ func (g *GitHub) CreatePR(ctx context.Context, opts CreatePROptions) (*types.Pul
 		Description: respBody.Body,
 		State:       respBody.State,
 		HeadBranch:  respBody.Head.Ref,
+		HeadSHA:     respBody.Head.SHA,
 		BaseBranch:  respBody.Base.Ref,
 		URL:         respBody.HTMLURL,
 		Draft:       respBody.Draft,
@@ -181,17 +193,19 @@ func (g *GitHub) CreatePR(ctx context.Context, opts CreatePROptions) (*types.Pul
 // GetPR retrieves a pull request by number.
 func (g *GitHub) GetPR(ctx context.Context, number int) (*types.PullRequest, error) {
 	var gh struct {
-		Number  int    `json:"number"`
-		Title   string `json:"title"`
-		Body    string `json:"body"`
-		State   string `json:"state"`
-		HTMLURL string `json:"html_url"`
-		Draft   bool   `json:"draft"`
+		Number  int     `json:"number"`
+		Title   string  `json:"title"`
+		Body    string  `json:"body"`
+		State   string  `json:"state"`
+		HTMLURL string  `json:"html_url"`
+		Draft   bool    `json:"draft"`
+		Merged  *string `json:"merged_at"`
 		User    struct {
 			Login string `json:"login"`
 		} `json:"user"`
 		Head struct {
 			Ref string `json:"ref"`
+			SHA string `json:"sha"`
 		} `json:"head"`
 		Base struct {
 			Ref string `json:"ref"`
@@ -210,57 +224,179 @@ func (g *GitHub) GetPR(ctx context.Context, number int) (*types.PullRequest, err
 		State:       gh.State,
 		Author:      gh.User.Login,
 		HeadBranch:  gh.Head.Ref,
+		HeadSHA:     gh.Head.SHA,
 		BaseBranch:  gh.Base.Ref,
 		URL:         gh.HTMLURL,
 		Draft:       gh.Draft,
+		Merged:      gh.Merged != nil,
 	}, nil
 }
 
-// ListPRs lists pull requests in the provided state.
+// ListPRs lists pull requests in the provided state, following pagination.
 func (g *GitHub) ListPRs(ctx context.Context, state string) ([]*types.PullRequest, error) {
 	if state == "" {
 		state = "open"
 	}
+	return g.listPRs(ctx, url.Values{"state": {state}})
+}
 
-	path := fmt.Sprintf("/pulls?state=%s", state)
+// ListBranchPRs lists pull requests in the provided state whose head is
+// branch in this repository.
+func (g *GitHub) ListBranchPRs(ctx context.Context, branch string, state string) ([]*types.PullRequest, error) {
+	if state == "" {
+		state = "open"
+	}
+	return g.listPRs(ctx, url.Values{"state": {state}, "head": {g.owner + ":" + branch}})
+}
 
-	var gh []struct {
-		Number  int    `json:"number"`
-		Title   string `json:"title"`
-		State   string `json:"state"`
-		HTMLURL string `json:"html_url"`
-		Draft   bool   `json:"draft"`
-		User    struct {
-			Login string `json:"login"`
-		} `json:"user"`
-		Head struct {
-			Ref string `json:"ref"`
-		} `json:"head"`
-		Base struct {
-			Ref string `json:"ref"`
-		} `json:"base"`
+// githubPull is a pull request as returned by the list endpoint.
+type githubPull struct {
+	Number  int     `json:"number"`
+	Title   string  `json:"title"`
+	State   string  `json:"state"`
+	HTMLURL string  `json:"html_url"`
+	Draft   bool    `json:"draft"`
+	Merged  *string `json:"merged_at"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Head struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func (g *GitHub) listPRs(ctx context.Context, query url.Values) ([]*types.PullRequest, error) {
+	query.Set("per_page", "100")
+	next := g.repoURL("/pulls?" + query.Encode())
+
+	var out []*types.PullRequest
+	for next != "" {
+		var page []githubPull
+		resp, err := g.send(ctx, http.MethodGet, next, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range page {
+			out = append(out, &types.PullRequest{
+				Number:     pr.Number,
+				Title:      pr.Title,
+				State:      pr.State,
+				Author:     pr.User.Login,
+				HeadBranch: pr.Head.Ref,
+				HeadSHA:    pr.Head.SHA,
+				BaseBranch: pr.Base.Ref,
+				URL:        pr.HTMLURL,
+				Draft:      pr.Draft,
+				Merged:     pr.Merged != nil,
+			})
+		}
+		next = nextPageURL(resp.Header.Get("Link"))
+	}
+
+	return out, nil
+}
+
+// RenameBranch renames a branch on GitHub. GitHub updates open pull requests
+// whose head or base is the branch.
+func (g *GitHub) RenameBranch(ctx context.Context, from string, to string) error {
+	path := fmt.Sprintf("/branches/%s/rename", url.PathEscape(from))
+	_, err := g.do(ctx, http.MethodPost, path, map[string]any{"new_name": to}, nil)
+	return err
+}
+
+// CheckStatus summarises the check runs for a ref: failure when any run
+// failed, pending while any is still running, success otherwise.
+func (g *GitHub) CheckStatus(ctx context.Context, ref string) (string, error) {
+	var resp struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+
+	path := fmt.Sprintf("/commits/%s/check-runs?per_page=100", url.PathEscape(ref))
+	if _, err := g.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.CheckRuns) == 0 {
+		return ChecksNone, nil
+	}
+
+	state := ChecksSuccess
+	for _, run := range resp.CheckRuns {
+		switch {
+		case run.Status != "completed":
+			state = ChecksPending
+		case run.Conclusion == "failure", run.Conclusion == "timed_out",
+			run.Conclusion == "cancelled", run.Conclusion == "action_required":
+			return ChecksFailure, nil
+		}
 	}
+	return state, nil
+}
 
-	_, err := g.do(ctx, http.MethodGet, path, nil, &gh)
+// Access reads the token's classic scopes and rate limit from the response
+// headers of the repository request, and the token's role from its
+// permissions. Pull requests and releases both need push access, and classic
+// tokens also need the repo or public_repo scope.
+func (g *GitHub) Access(ctx context.Context) (*AccessReport, error) {
+	var repo struct {
+		Private     bool `json:"private"`
+		Permissions struct {
+			Admin    bool `json:"admin"`
+			Maintain bool `json:"maintain"`
+			Push     bool `json:"push"`
+			Triage   bool `json:"triage"`
+			Pull     bool `json:"pull"`
+		} `json:"permissions"`
+	}
+
+	resp, err := g.do(ctx, http.MethodGet, "", nil, &repo)
 	if err != nil {
 		return nil, err
 	}
 
-	out := make([]*types.PullRequest, 0, len(gh))
-	for _, pr := range gh {
-		out = append(out, &types.PullRequest{
-			Number:     pr.Number,
-			Title:      pr.Title,
-			State:      pr.State,
-			Author:     pr.User.Login,
-			HeadBranch: pr.Head.Ref,
-			BaseBranch: pr.Base.Ref,
-			URL:        pr.HTMLURL,
-			Draft:      pr.Draft,
-		})
+	report := &AccessReport{RateLimit: rateLimitFromHeaders(resp.Header, "X-RateLimit-")}
+	if values, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		report.Scopes = []string{}
+		for _, s := range strings.Split(strings.Join(values, ","), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				report.Scopes = append(report.Scopes, s)
+			}
+		}
+	}
+	if exp := resp.Header.Get("GitHub-Authentication-Token-Expiration"); exp != "" {
+		report.ExpiresAt, _ = time.Parse("2006-01-02 15:04:05 MST", exp)
 	}
 
-	return out, nil
+	p := repo.Permissions
+	switch {
+	case p.Admin:
+		report.Role = "admin"
+	case p.Maintain:
+		report.Role = "maintain"
+	case p.Push:
+		report.Role = "push"
+	case p.Triage:
+		report.Role = "triage"
+	case p.Pull:
+		report.Role = "pull"
+	default:
+		report.Role = "none"
+	}
+
+	scopesOK := report.Scopes == nil || hasScope(report.Scopes, "repo")
+	if !repo.Private && !scopesOK {
+		scopesOK = hasScope(report.Scopes, "public_repo")
+	}
+	canWrite := p.Admin || p.Maintain || p.Push
+	report.CanCreatePR = canWrite && scopesOK
+	report.CanCreateRelease = canWrite && scopesOK
+	return report, nil
 }
 
 // CreateRelease creates a release for a tag.
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGitHubRenameBranch(t *testing.T) {
+	var sawRename bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.EscapedPath() != "/repos/acme/repo/branches/feature%2Ftpyo/rename" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["new_name"] != "feature/typo" {
+			t.Fatalf("unexpected new_name: %v", body["new_name"])
+		}
+		sawRename = true
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"name": "feature/typo"}`))
+	}))
+	defer server.Close()
+
+	g, err := NewGitHub(ProviderConfig{
+		Type:    "github",
+		BaseURL: server.URL,
+		Token:   "testtoken",
+		Owner:   "acme",
+		Repo:    "repo",
+	})
+	if err != nil {
+		t.Fatalf("NewGitHub: %v", err)
+	}
+
+	var p Provider = g
+	renamer, ok := p.(BranchRenamer)
+	if !ok {
+		t.Fatalf("expected GitHub to implement BranchRenamer")
+	}
+	if err := renamer.RenameBranch(context.Background(), "feature/tpyo", "feature/typo"); err != nil {
+		t.Fatalf("RenameBranch: %v", err)
+	}
+	if !sawRename {
+		t.Fatalf("expected rename request")
+	}
+}
+
+func TestGitHubCheckStatus(t *testing.T) {
+	runs := map[string]string{
+		"feature/green": `{"check_runs": [{"status": "completed", "conclusion": "success"}, {"status": "completed", "conclusion": "skipped"}]}`,
+		"feature/busy":  `{"check_runs": [{"status": "completed", "conclusion": "success"}, {"status": "in_progress", "conclusion": null}]}`,
+		"feature/red":   `{"check_runs": [{"status": "in_progress", "conclusion": null}, {"status": "completed", "conclusion": "failure"}]}`,
+		"feature/none":  `{"check_runs": []}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ref := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/acme/repo/commits/"), "/check-runs")
+		body, ok := runs[ref]
+		if !ok {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	g, err := NewGitHub(ProviderConfig{Type: "github", BaseURL: server.URL, Token: "t", Owner: "acme", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("NewGitHub: %v", err)
+	}
+
+	want := map[string]string{
+		"feature/green": ChecksSuccess,
+		"feature/busy":  ChecksPending,
+		"feature/red":   ChecksFailure,
+		"feature/none":  ChecksNone,
+	}
+	for ref, state := range want {
+		got, err := g.CheckStatus(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("CheckStatus(%s): %v", ref, err)
+		}
+		if got != state {
+			t.Fatalf("CheckStatus(%s) = %s, want %s", ref, got, state)
+		}
+	}
+}
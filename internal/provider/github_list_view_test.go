@@ -82,3 +82,61 @@ func TestGitHubListPRsAndGetPR(t *testing.T) {
 		t.Fatalf("expected details")
 	}
 }
+
+func TestGitHubListPRsFollowsPagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/repo/pulls" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("per_page") != "100" || q.Get("state") != "all" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if q.Get("page") == "" {
+			w.Header().Set("Link", `<`+server.URL+`/repos/acme/repo/pulls?state=all&per_page=100&page=2>; rel="next", <`+server.URL+`/repos/acme/repo/pulls?state=all&per_page=100&page=2>; rel="last"`)
+			w.Write([]byte(`[{"number": 2, "head": {"ref": "feature/b", "sha": "bbb"}}]`))
+			return
+		}
+		w.Write([]byte(`[{"number": 1, "head": {"ref": "feature/a", "sha": "aaa"}}]`))
+	}))
+	defer server.Close()
+
+	g, err := NewGitHub(ProviderConfig{Type: "github", BaseURL: server.URL, Token: "t", Owner: "acme", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("NewGitHub: %v", err)
+	}
+
+	prs, err := g.ListPRs(context.Background(), "all")
+	if err != nil {
+		t.Fatalf("ListPRs: %v", err)
+	}
+	if len(prs) != 2 || prs[1].HeadBranch != "feature/a" || prs[1].HeadSHA != "aaa" {
+		t.Fatalf("expected both pages, got %+v", prs)
+	}
+}
+
+func TestGitHubListBranchPRsFiltersByHead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if head := r.URL.Query().Get("head"); head != "acme:feature/a" {
+			t.Fatalf("unexpected head filter %q", head)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"number": 1, "state": "open", "head": {"ref": "feature/a"}}]`))
+	}))
+	defer server.Close()
+
+	g, err := NewGitHub(ProviderConfig{Type: "github", BaseURL: server.URL, Token: "t", Owner: "acme", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("NewGitHub: %v", err)
+	}
+
+	prs, err := g.ListBranchPRs(context.Background(), "feature/a", "open")
+	if err != nil {
+		t.Fatalf("ListBranchPRs: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Number != 1 {
+		t.Fatalf("unexpected prs %+v", prs)
+	}
+}
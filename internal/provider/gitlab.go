@@ -140,9 +140,84 @@ func (g *GitLab) UpdateRelease(tag string, name string, body string) (*types.Rel
 	}, nil
 }
 
+// Access reads the token's scopes and expiry from personal_access_tokens/self
+// and its role from the project's permissions. Merge requests and releases
+// need the api scope and at least the Developer role.
+func (g *GitLab) Access(ctx context.Context) (*AccessReport, error) {
+	var project struct {
+		Permissions struct {
+			ProjectAccess *struct {
+				AccessLevel int `json:"access_level"`
+			} `json:"project_access"`
+			GroupAccess *struct {
+				AccessLevel int `json:"access_level"`
+			} `json:"group_access"`
+		} `json:"permissions"`
+	}
+
+	resp, err := g.do(ctx, http.MethodGet, "", nil, &project)
+	if err != nil {
+		return nil, err
+	}
+	report := &AccessReport{RateLimit: rateLimitFromHeaders(resp.Header, "RateLimit-")}
+
+	level := 0
+	if a := project.Permissions.ProjectAccess; a != nil && a.AccessLevel > level {
+		level = a.AccessLevel
+	}
+	if a := project.Permissions.GroupAccess; a != nil && a.AccessLevel > level {
+		level = a.AccessLevel
+	}
+	report.Role = gitlabRole(level)
+
+	// OAuth and job tokens are not personal access tokens; their scopes stay
+	// unknown and only the role is checked.
+	var token struct {
+		Scopes    []string `json:"scopes"`
+		ExpiresAt string   `json:"expires_at"`
+	}
+	if _, err := g.send(ctx, http.MethodGet, g.baseURL+"/personal_access_tokens/self", nil, &token); err == nil {
+		report.Scopes = token.Scopes
+		if report.Scopes == nil {
+			report.Scopes = []string{}
+		}
+		if token.ExpiresAt != "" {
+			report.ExpiresAt, _ = time.Parse("2006-01-02", token.ExpiresAt)
+		}
+	}
+
+	scopesOK := report.Scopes == nil || hasScope(report.Scopes, "api")
+	report.CanCreatePR = level >= gitlabDeveloper && scopesOK
+	report.CanCreateRelease = level >= gitlabDeveloper && scopesOK
+	return report, nil
+}
+
+const gitlabDeveloper = 30
+
+func gitlabRole(level int) string {
+	switch {
+	case level >= 50:
+		return "owner"
+	case level >= 40:
+		return "maintainer"
+	case level >= gitlabDeveloper:
+		return "developer"
+	case level >= 20:
+		return "reporter"
+	case level >= 10:
+		return "guest"
+	default:
+		return "none"
+	}
+}
+
 func (g *GitLab) do(ctx context.Context, method string, path string, body any, out any) (*http.Response, error) {
-	url := fmt.Sprintf("%s/projects/%s%s", g.baseURL, g.project, path)
+	return g.send(ctx, method, fmt.Sprintf("%s/projects/%s%s", g.baseURL, g.project, path), body, out)
+}
 
+// send executes a request against an absolute API URL and optionally decodes
+// JSON.
+func (g *GitLab) send(ctx context.Context, method string, url string, body any, out any) (*http.Response, error) {
 	var r io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
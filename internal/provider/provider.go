@@ -5,6 +5,10 @@ import (
 	"context"
 	"fmt"
 	"gitflow/pkg/types"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Provider defines the hosting provider behaviors needed by the app.
@@ -18,6 +22,60 @@ type Provider interface {
 	UpdateRelease(tag string, name string, body string) (*types.Release, error)
 }
 
+// BranchRenamer is implemented by providers that can rename a remote branch
+// and retarget the pull requests using it as head or base.
+type BranchRenamer interface {
+	RenameBranch(ctx context.Context, from string, to string) error
+}
+
+// BranchPRLister is implemented by providers that can list the pull requests
+// for one head branch without listing every pull request.
+type BranchPRLister interface {
+	ListBranchPRs(ctx context.Context, branch string, state string) ([]*types.PullRequest, error)
+}
+
+// Check states reported by CheckReporter.
+const (
+	ChecksNone    = "none"
+	ChecksPending = "pending"
+	ChecksSuccess = "success"
+	ChecksFailure = "failure"
+)
+
+// CheckReporter is implemented by providers that report CI check results.
+type CheckReporter interface {
+	CheckStatus(ctx context.Context, ref string) (string, error)
+}
+
+// AccessReport describes what the configured token may do on the repository.
+type AccessReport struct {
+	// Scopes lists the token's scopes. It is nil when the provider does not
+	// report them, as for GitHub fine-grained tokens.
+	Scopes []string
+	// Role is the token's access to the repository, such as push or developer.
+	Role      string
+	ExpiresAt time.Time
+
+	CanCreatePR      bool
+	CanCreateRelease bool
+
+	RateLimit RateLimit
+}
+
+// RateLimit is the API request allowance reported with the last response.
+// Limit is zero when the provider sent no rate-limit headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// AccessInspector is implemented by providers that report token scopes,
+// repository permissions and rate limits.
+type AccessInspector interface {
+	Access(ctx context.Context) (*AccessReport, error)
+}
+
 // CreatePROptions defines pull request creation inputs.
 type CreatePROptions struct {
 	Title       string
@@ -52,3 +110,42 @@ func New(cfg ProviderConfig) (Provider, error) {
 		return nil, fmt.Errorf("unsupported provider type: %s", cfg.Type)
 	}
 }
+
+// rateLimitFromHeaders reads Limit, Remaining and Reset headers with the
+// given prefix, such as X-RateLimit- on GitHub or RateLimit- on GitLab.
+func rateLimitFromHeaders(h http.Header, prefix string) RateLimit {
+	limit, err := strconv.Atoi(h.Get(prefix + "Limit"))
+	if err != nil {
+		return RateLimit{}
+	}
+	remaining, _ := strconv.Atoi(h.Get(prefix + "Remaining"))
+	rl := RateLimit{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl
+}
+
+func hasScope(scopes []string, want ...string) bool {
+	for _, s := range scopes {
+		for _, w := range want {
+			if strings.EqualFold(strings.TrimSpace(s), w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextPageURL returns the rel="next" URL from a Link header, or "" on the
+// last page.
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(part, ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(target), "<>")
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// CleanupChoice describes a branch offered for deletion.
+type CleanupChoice struct {
+	Name       string
+	Reason     string
+	LastCommit string
+	AgeDays    int
+	Ahead      int
+	Behind     int
+	Unmerged   bool
+}
+
+// CleanupSummary lists what a cleanup is about to delete.
+type CleanupSummary struct {
+	Local      []string
+	Remote     []string
+	RemoteName string
+	Unmerged   []string
+}
+
+// PromptCleanupSelection asks the user which branches to delete.
+func PromptCleanupSelection(title string, choices []CleanupChoice) ([]string, error) {
+	options := make([]huh.Option[string], 0, len(choices))
+	for _, c := range choices {
+		options = append(options, huh.NewOption(cleanupChoiceLabel(c), c.Name))
+	}
+
+	var selected []string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(title).
+				Description("Branches marked ! are not merged and will be force deleted").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// ConfirmCleanup shows the pending deletions and asks for confirmation.
+func ConfirmCleanup(summary CleanupSummary) (bool, error) {
+	confirmed := false
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(cleanupSummaryTitle(summary)).
+				Description(cleanupSummaryBody(summary)).
+				Affirmative("Delete").
+				Negative("Cancel").
+				Value(&confirmed),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return false, err
+	}
+	return confirmed, nil
+}
+
+func cleanupChoiceLabel(c CleanupChoice) string {
+	mark := " "
+	if c.Unmerged {
+		mark = "!"
+	}
+	label := fmt.Sprintf("%s %s  %s  %dd  +%d/-%d", mark, c.Name, c.Reason, c.AgeDays, c.Ahead, c.Behind)
+	if c.LastCommit != "" {
+		label += "  " + truncate(c.LastCommit, 50)
+	}
+	return label
+}
+
+func cleanupSummaryTitle(s CleanupSummary) string {
+	var parts []string
+	if len(s.Local) > 0 {
+		parts = append(parts, fmt.Sprintf("%d local", len(s.Local)))
+	}
+	if len(s.Remote) > 0 {
+		parts = append(parts, fmt.Sprintf("%d remote", len(s.Remote)))
+	}
+	return fmt.Sprintf("Delete %s branch(es)?", strings.Join(parts, " and "))
+}
+
+func cleanupSummaryBody(s CleanupSummary) string {
+	var b strings.Builder
+	if len(s.Local) > 0 {
+		b.WriteString("Local:\n")
+		for _, name := range s.Local {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	if len(s.Remote) > 0 {
+		fmt.Fprintf(&b, "Remote (%s):\n", s.RemoteName)
+		for _, name := range s.Remote {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	if len(s.Unmerged) > 0 {
+		b.WriteString("Not merged, force deleted:\n")
+		for _, name := range s.Unmerged {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	b.WriteString("Deleted branches can be restored with gitflow cleanup --undo")
+	return b.String()
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-3]) + "..."
+}
@@ -100,3 +100,35 @@ func PromptCommit(types []string, scopes []string, requireScope bool, defaults C
 
 	return in, nil
 }
+
+// CommitChoice describes a commit offered for selection.
+type CommitChoice struct {
+	Hash    string
+	Subject string
+}
+
+// PromptCommitTarget asks the user to pick one commit and returns its hash.
+func PromptCommitTarget(title string, choices []CommitChoice) (string, error) {
+	options := make([]huh.Option[string], 0, len(choices))
+	for _, c := range choices {
+		short := c.Hash
+		if len(short) > 7 {
+			short = short[:7]
+		}
+		options = append(options, huh.NewOption(short+"  "+c.Subject, c.Hash))
+	}
+
+	var selected string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(title).
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return "", err
+	}
+	return selected, nil
+}
@@ -0,0 +1,22 @@
+package ui
+
+import "github.com/charmbracelet/huh"
+
+// ConfirmDoctorFix asks whether doctor should apply a fix for a check.
+func ConfirmDoctorFix(check, description string) (bool, error) {
+	confirmed := false
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Fix " + check + "?").
+				Description(description).
+				Affirmative("Apply").
+				Negative("Skip").
+				Value(&confirmed),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return false, err
+	}
+	return confirmed, nil
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// InitPromptInput captures prompt fields for gitflow init.
+type InitPromptInput struct {
+	Provider     string
+	Owner        string
+	Repo         string
+	MainBranch   string
+	BaseBranch   string
+	Conventional bool
+	Scopes       string
+	// History describes the commits behind the conventional commit default.
+	History string
+}
+
+// PromptInit asks for the settings gitflow init writes, starting from the
+// detected defaults.
+func PromptInit(defaults InitPromptInput) (InitPromptInput, error) {
+	in := defaults
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Provider").
+				Options(
+					huh.NewOption("GitHub", "github"),
+					huh.NewOption("GitLab", "gitlab"),
+					huh.NewOption("None", ""),
+				).
+				Value(&in.Provider),
+
+			huh.NewInput().
+				Title("Owner").
+				Description("User, organisation or group").
+				Value(&in.Owner).
+				Placeholder("acme"),
+
+			huh.NewInput().
+				Title("Repository").
+				Value(&in.Repo).
+				Placeholder("api"),
+		),
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Default branch").
+				Value(&in.MainBranch).
+				Placeholder("main"),
+
+			huh.NewInput().
+				Title("Start new branches from").
+				Value(&in.BaseBranch).
+				Placeholder("develop"),
+		),
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Use conventional commits").
+				Description(in.History).
+				Value(&in.Conventional),
+
+			huh.NewInput().
+				Title("Commit scopes (comma separated)").
+				Value(&in.Scopes).
+				Placeholder("api,cli"),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return InitPromptInput{}, err
+	}
+
+	in.Owner = strings.TrimSpace(in.Owner)
+	in.Repo = strings.TrimSpace(in.Repo)
+	in.MainBranch = strings.TrimSpace(in.MainBranch)
+	in.BaseBranch = strings.TrimSpace(in.BaseBranch)
+	in.Scopes = strings.TrimSpace(in.Scopes)
+
+	return in, nil
+}
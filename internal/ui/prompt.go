@@ -71,3 +71,43 @@ func PromptPR(defaults PRPromptInput) (PRPromptInput, error) {
 
 	return in, nil
 }
+
+// BranchMetaInput captures prompt fields for branch metadata.
+type BranchMetaInput struct {
+	Description string
+	Issue       string
+	Parent      string
+}
+
+// PromptBranchMeta lets the user edit branch metadata.
+func PromptBranchMeta(branch string, defaults BranchMetaInput) (BranchMetaInput, error) {
+	in := defaults
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title("Description for "+branch).
+				Description("The first line is used as the pull request title").
+				Value(&in.Description),
+
+			huh.NewInput().
+				Title("Issue").
+				Value(&in.Issue).
+				Placeholder("PROJ-42 or 42"),
+
+			huh.NewInput().
+				Title("Parent branch").
+				Value(&in.Parent).
+				Placeholder("main"),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return BranchMetaInput{}, err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	in.Issue = strings.TrimSpace(in.Issue)
+	in.Parent = strings.TrimSpace(in.Parent)
+	return in, nil
+}
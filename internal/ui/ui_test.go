@@ -30,3 +30,19 @@ func TestUIWrites(t *testing.T) {
 		t.Fatalf("expected ok prefix")
 	}
 }
+
+func TestCleanupLabelsMarkUnmergedAndSummarise(t *testing.T) {
+	label := cleanupChoiceLabel(CleanupChoice{Name: "feature/wip", Reason: "stale", LastCommit: "wip", AgeDays: 40, Ahead: 2, Unmerged: true})
+	if !strings.HasPrefix(label, "! feature/wip") || !strings.Contains(label, "+2/-0") || !strings.Contains(label, "wip") {
+		t.Fatalf("unexpected label %q", label)
+	}
+
+	s := CleanupSummary{Local: []string{"a", "b"}, Remote: []string{"a"}, RemoteName: "origin", Unmerged: []string{"b"}}
+	if got := cleanupSummaryTitle(s); got != "Delete 2 local and 1 remote branch(es)?" {
+		t.Fatalf("unexpected title %q", got)
+	}
+	body := cleanupSummaryBody(s)
+	if !strings.Contains(body, "Remote (origin):\n  a") || !strings.Contains(body, "force deleted:\n  b") {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
@@ -0,0 +1,109 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"gitflow/internal/git"
+)
+
+// AutostashResult reports what happened to changes stashed around a workflow.
+type AutostashResult struct {
+	Hash     string
+	Message  string
+	Restored bool
+	Conflict []string
+	Err      string
+}
+
+// autostash tracks a stash created on behalf of a workflow.
+type autostash struct {
+	client  *git.Client
+	hash    string
+	message string
+}
+
+// beginAutostash stashes a dirty working tree when enabled and refuses to
+// continue with a dirty tree otherwise. It returns nil when nothing was stashed.
+func beginAutostash(client *git.Client, enabled bool, label string) (*autostash, error) {
+	dirty, err := client.IsDirty()
+	if err != nil {
+		return nil, err
+	}
+	if !dirty {
+		return nil, nil
+	}
+	if !enabled {
+		return nil, fmt.Errorf("working tree is not clean, commit or stash changes or use --autostash")
+	}
+
+	message := fmt.Sprintf("gitflow autostash: %s %s", label, time.Now().UTC().Format(time.RFC3339))
+	hash, err := client.StashPush(message)
+	if err != nil {
+		return nil, err
+	}
+	return &autostash{client: client, hash: hash, message: message}, nil
+}
+
+// resumeAutostash rebuilds a stash handle recorded in workflow state.
+func resumeAutostash(client *git.Client, hash string, message string) *autostash {
+	if hash == "" {
+		return nil
+	}
+	return &autostash{client: client, hash: hash, message: message}
+}
+
+// restore pops the stash. A failed pop leaves the stash in place and is
+// reported in the result rather than as an error, since the workflow itself
+// has already completed.
+func (a *autostash) restore() *AutostashResult {
+	if a == nil {
+		return nil
+	}
+
+	res := &AutostashResult{Hash: a.hash, Message: a.message}
+	if err := a.client.StashPop(a.hash); err != nil {
+		res.Err = err.Error()
+		files, _ := a.client.ConflictedFiles()
+		res.Conflict = files
+		return res
+	}
+	res.Restored = true
+	return res
+}
+
+// restoreAfter pops the stash after a failed workflow and folds a failed pop
+// into the returned error.
+func (a *autostash) restoreAfter(err error) error {
+	res := a.restore()
+	if res == nil || res.Restored {
+		return err
+	}
+	return fmt.Errorf("%w\n%s", err, res.Summary())
+}
+
+// Stashed reports whether changes were stashed; it is false for a nil result.
+func (r *AutostashResult) Stashed() bool {
+	return r != nil
+}
+
+// Summary describes a stash that could not be restored.
+func (r *AutostashResult) Summary() string {
+	if r == nil || r.Restored {
+		return ""
+	}
+	msg := fmt.Sprintf("autostashed changes could not be restored and were kept as %q", r.Message)
+	if len(r.Conflict) > 0 {
+		msg += fmt.Sprintf("; conflicts in %d file(s)", len(r.Conflict))
+		for _, f := range r.Conflict {
+			msg += "\n  " + f
+		}
+		msg += "\nResolve the conflicts, then drop the stash with git stash drop"
+	} else {
+		if r.Err != "" {
+			msg += "\n" + r.Err
+		}
+		msg += "\nApply it with git stash pop once the working tree allows it"
+	}
+	return msg
+}
@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+func TestSyncAutostashRestoresChanges(t *testing.T) {
+	_, a, _ := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/wip")
+	if err := os.WriteFile(filepath.Join(a, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.AutoPush = false
+	cfg.Workflows.Autostash = true
+
+	out, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if out.Autostash == nil || !out.Autostash.Restored {
+		t.Fatalf("expected autostash to be restored, got %+v", out.Autostash)
+	}
+
+	data, err := os.ReadFile(filepath.Join(a, "wip.txt"))
+	if err != nil || string(data) != "wip" {
+		t.Fatalf("expected untracked file to be restored, err %v", err)
+	}
+
+	client, _ := git.NewClient(a)
+	stashes, _ := client.Run("stash", "list")
+	if stashes != "" {
+		t.Fatalf("expected stash to be dropped, got %s", stashes)
+	}
+}
+
+func TestSyncWithoutAutostashRejectsDirtyTree(t *testing.T) {
+	_, a, _ := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/wip")
+	if err := os.WriteFile(filepath.Join(a, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Workflows.Autostash = true
+	disabled := false
+
+	_, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin", AutostashOverride: &disabled})
+	if err == nil || !strings.Contains(err.Error(), "not clean") {
+		t.Fatalf("expected dirty tree error, got %v", err)
+	}
+}
+
+func TestSyncAutostashKeepsStashOnPopConflict(t *testing.T) {
+	_, a, b := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/wip")
+
+	if err := os.WriteFile(filepath.Join(b, "README.md"), []byte("from main"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, b, "commit", "-am", "main readme")
+	runGitSync(t, b, "push", "origin", "main")
+
+	if err := os.WriteFile(filepath.Join(a, "README.md"), []byte("local wip"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.AutoPush = false
+	cfg.Workflows.Autostash = true
+
+	out, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if out.Autostash == nil || out.Autostash.Restored {
+		t.Fatalf("expected autostash pop to fail, got %+v", out.Autostash)
+	}
+	if len(out.Autostash.Conflict) != 1 || out.Autostash.Conflict[0] != "README.md" {
+		t.Fatalf("expected README.md conflict, got %v", out.Autostash.Conflict)
+	}
+
+	client, _ := git.NewClient(a)
+	stashes, _ := client.Run("stash", "list")
+	if !strings.Contains(stashes, "gitflow autostash: sync") {
+		t.Fatalf("expected labelled stash to be kept, got %q", stashes)
+	}
+}
+
+func TestSyncConflictMentionsAutostash(t *testing.T) {
+	repo, cfg := setupSyncConflict(t)
+	if err := os.WriteFile(filepath.Join(repo, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg.Workflows.Autostash = true
+
+	_, err := Sync(cfg, SyncOptions{RepoPath: repo, Remote: "origin"})
+	var conflict SyncConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected SyncConflictError, got %v", err)
+	}
+	if conflict.Stash == "" || !strings.Contains(err.Error(), "autostashed as "+conflict.Stash[:7]) || !strings.Contains(err.Error(), "git stash pop") {
+		t.Fatalf("expected the error to name the stash, got %v", err)
+	}
+
+	out, err := SyncAbort(cfg, SyncOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("SyncAbort: %v", err)
+	}
+	if out.Autostash == nil || !out.Autostash.Restored {
+		t.Fatalf("expected abort to restore the stash, got %+v", out.Autostash)
+	}
+}
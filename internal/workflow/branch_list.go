@@ -1,11 +1,15 @@
 package workflow
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"gitflow/internal/config"
 	"gitflow/internal/git"
+	"gitflow/internal/provider"
 	"gitflow/pkg/types"
 )
 
@@ -13,19 +17,48 @@ import (
 type BranchListOptions struct {
 	RepoPath string
 	Base     string
+
+	// Scope selects local, remote or all branches; it defaults to local.
+	Scope  string
+	Remote string
+
+	Mine   bool
+	Stale  int
+	Merged bool
+	Prefix string
+
+	// Sort orders by name, age, ahead or behind; it defaults to name.
+	Sort string
+
+	WithPRs bool
 }
 
+const (
+	// BranchScopeLocal lists local branches.
+	BranchScopeLocal = "local"
+	// BranchScopeRemote lists remote-tracking branches.
+	BranchScopeRemote = "remote"
+	// BranchScopeAll lists local and remote-tracking branches.
+	BranchScopeAll = "all"
+)
+
 // BranchListResult contains branch list results.
 type BranchListResult struct {
 	Base     string
+	Remote   string
 	Branches []*types.Branch
+	// Warnings reports merge checks that could not run.
+	Warnings []string
 }
 
-// ListBranches lists local branches and metadata.
+// ListBranches lists branches with metadata, filtered and sorted as requested.
 func ListBranches(cfg *config.Config, opts BranchListOptions) (*BranchListResult, error) {
 	if strings.TrimSpace(opts.RepoPath) == "" {
 		return nil, fmt.Errorf("repo path is required")
 	}
+	if opts.Remote == "" {
+		opts.Remote = "origin"
+	}
 
 	base := strings.TrimSpace(opts.Base)
 	if base == "" {
@@ -38,18 +71,228 @@ func ListBranches(cfg *config.Config, opts BranchListOptions) (*BranchListResult
 		base = "main"
 	}
 
-	client, err := git.NewClient(opts.RepoPath)
-	if err != nil {
+	scope := opts.Scope
+	if scope == "" {
+		scope = BranchScopeLocal
+	}
+	if scope != BranchScopeLocal && scope != BranchScopeRemote && scope != BranchScopeAll {
+		return nil, fmt.Errorf("unsupported branch scope: %s", scope)
+	}
+	if err := validateBranchSort(opts.Sort); err != nil {
 		return nil, err
 	}
 
-	branches, err := client.ListLocalBranches(base)
+	client, err := git.NewClient(opts.RepoPath)
 	if err != nil {
 		return nil, err
 	}
 
+	var branches []*types.Branch
+
+	if scope != BranchScopeRemote {
+		local, err := client.ListLocalBranches(base)
+		if err != nil {
+			return nil, err
+		}
+
+		metas, err := client.AllBranchMeta()
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range local {
+			if m, ok := metas[b.Name]; ok {
+				b.Description = m.Title()
+				b.Issue = m.Issue
+				b.Parent = m.Parent
+			}
+		}
+		branches = append(branches, local...)
+	}
+
+	if scope != BranchScopeLocal {
+		remote, err := client.ListRemoteBranches(opts.Remote, base)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range remote {
+			b.Remote = opts.Remote
+		}
+		branches = append(branches, remote...)
+	}
+
+	var me string
+	if opts.Mine {
+		me, _ = client.ConfigValue("user.name")
+		if strings.TrimSpace(me) == "" {
+			return nil, fmt.Errorf("--mine needs git user.name to be set")
+		}
+	}
+
+	filtered := branches[:0]
+	for _, b := range branches {
+		if opts.Mine && b.Author != me {
+			continue
+		}
+		if opts.Stale > 0 && b.AgeDays < opts.Stale {
+			continue
+		}
+		if opts.Prefix != "" && !strings.HasPrefix(b.Name, opts.Prefix) {
+			continue
+		}
+		filtered = append(filtered, b)
+	}
+
+	warnings := markMerged(cfg, client, opts, base, filtered)
+	if opts.Merged {
+		merged := filtered[:0]
+		for _, b := range filtered {
+			if b.Merged {
+				merged = append(merged, b)
+			}
+		}
+		filtered = merged
+	}
+	sortBranches(filtered, opts.Sort)
+
+	if opts.WithPRs {
+		if err := attachPRs(cfg, filtered); err != nil {
+			return nil, err
+		}
+	}
+
 	return &BranchListResult{
 		Base:     base,
-		Branches: branches,
+		Remote:   opts.Remote,
+		Branches: filtered,
+		Warnings: warnings,
 	}, nil
 }
+
+// markMerged flags branches that landed on the base by a merge, a squash or
+// a rebase, using the same checks as cleanup. Merged pull requests are only
+// looked up when filtering with --merged. A missing base branch leaves every
+// branch unmerged rather than failing the listing.
+func markMerged(cfg *config.Config, client *git.Client, opts BranchListOptions, base string, branches []*types.Branch) []string {
+	// Detectors are keyed by remote, with local branches under "".
+	detectors := make(map[string]*mergeDetector)
+	for _, b := range branches {
+		if b.Name == base {
+			continue
+		}
+		d, ok := detectors[b.Remote]
+		if !ok {
+			var err error
+			if b.Remote == "" {
+				d, err = newMergeDetector(client, base)
+			} else {
+				d, err = newRemoteMergeDetector(client, b.Remote, base)
+			}
+			if err != nil {
+				d = nil
+			} else if opts.Merged {
+				d.useProvider(cfg)
+			}
+			detectors[b.Remote] = d
+		}
+		if d != nil {
+			b.Merged = d.detect(b.Name) != ""
+		}
+	}
+
+	var warnings []string
+	for _, remote := range []string{"", opts.Remote} {
+		if d := detectors[remote]; d != nil {
+			warnings = append(warnings, d.warnings...)
+		}
+	}
+	return warnings
+}
+
+func validateBranchSort(by string) error {
+	switch by {
+	case "", "name", "age", "ahead", "behind":
+		return nil
+	default:
+		return fmt.Errorf("unsupported sort %q, expected name, age, ahead or behind", by)
+	}
+}
+
+// sortBranches orders branches by name, or by age, ahead or behind with the
+// largest values first. Local branches come before remote ones with the same name.
+func sortBranches(branches []*types.Branch, by string) {
+	sort.SliceStable(branches, func(i, j int) bool {
+		a, b := branches[i], branches[j]
+		switch by {
+		case "age":
+			if a.AgeDays != b.AgeDays {
+				return a.AgeDays > b.AgeDays
+			}
+		case "ahead":
+			if a.Ahead != b.Ahead {
+				return a.Ahead > b.Ahead
+			}
+		case "behind":
+			if a.Behind != b.Behind {
+				return a.Behind > b.Behind
+			}
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Remote < b.Remote
+	})
+}
+
+// attachPRs fills in the pull request for each branch, preferring an open
+// pull request over older closed ones. Only the listed branches are looked
+// up, once per name.
+func attachPRs(cfg *config.Config, branches []*types.Branch) error {
+	if !provider.Enabled(cfg) {
+		return fmt.Errorf("provider is not configured in .gitflow.yml")
+	}
+	pcfg, err := provider.FromAppConfig(cfg)
+	if err != nil {
+		return ProviderError{Err: err}
+	}
+	p, err := provider.New(pcfg)
+	if err != nil {
+		return ProviderError{Err: err}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	byHead := make(map[string]*types.PullRequest)
+	for _, b := range branches {
+		pr, seen := byHead[b.Name]
+		if !seen {
+			prs, err := branchPRs(ctx, p, b.Name, "all")
+			if err != nil {
+				return ProviderError{Err: err}
+			}
+			for _, candidate := range prs {
+				if pr == nil || (pr.State != "open" && candidate.State == "open") {
+					pr = candidate
+				}
+			}
+			byHead[b.Name] = pr
+		}
+		if pr == nil {
+			continue
+		}
+		b.PRNumber = pr.Number
+		b.PRState = prState(pr)
+	}
+	return nil
+}
+
+func prState(pr *types.PullRequest) string {
+	switch {
+	case pr.Merged:
+		return "merged"
+	case pr.State == "open" && pr.Draft:
+		return "draft"
+	default:
+		return pr.State
+	}
+}
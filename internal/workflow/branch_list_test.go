@@ -1,6 +1,10 @@
 package workflow
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"gitflow/internal/config"
@@ -24,3 +28,131 @@ func TestWorkflowListBranches(t *testing.T) {
 		t.Fatalf("expected branches")
 	}
 }
+
+func TestListBranchesScopesAndFilters(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "branch", "feature/done")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/wip")
+	commitFile(t, repo, "wip.txt", "one", "wip one")
+	commitFile(t, repo, "wip2.txt", "two", "wip two")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/wip")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "bugfix/other", "main")
+	runGitCleanup(t, repo, []string{"GIT_AUTHOR_NAME=Someone Else"}, "commit", "--allow-empty", "-m", "other")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+
+	cfg := config.Default()
+	cfg.Branches.MainBranch = "main"
+
+	names := func(res *BranchListResult) []string {
+		var out []string
+		for _, b := range res.Branches {
+			name := b.Name
+			if b.Remote != "" {
+				name = b.Remote + "/" + name
+			}
+			out = append(out, name)
+		}
+		return out
+	}
+
+	res, err := ListBranches(cfg, BranchListOptions{RepoPath: repo, Scope: BranchScopeRemote})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if got := names(res); !reflect.DeepEqual(got, []string{"origin/feature/wip", "origin/main"}) {
+		t.Fatalf("remote branches = %v", got)
+	}
+	if res.Branches[0].Ahead != 2 {
+		t.Fatalf("expected origin/feature/wip to be 2 ahead, got %d", res.Branches[0].Ahead)
+	}
+
+	res, err = ListBranches(cfg, BranchListOptions{RepoPath: repo, Scope: BranchScopeAll, Prefix: "feature/", Sort: "ahead"})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if got := names(res); !reflect.DeepEqual(got, []string{"feature/wip", "origin/feature/wip", "feature/done"}) {
+		t.Fatalf("sorted by ahead = %v", got)
+	}
+
+	res, err = ListBranches(cfg, BranchListOptions{RepoPath: repo, Merged: true})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if got := names(res); !reflect.DeepEqual(got, []string{"feature/done"}) {
+		t.Fatalf("merged branches = %v", got)
+	}
+
+	res, err = ListBranches(cfg, BranchListOptions{RepoPath: repo, Mine: true})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	for _, name := range names(res) {
+		if name == "bugfix/other" {
+			t.Fatalf("--mine kept a branch by another author: %v", names(res))
+		}
+	}
+
+	res, err = ListBranches(cfg, BranchListOptions{RepoPath: repo, Stale: 30})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if len(res.Branches) != 0 {
+		t.Fatalf("expected no stale branches, got %v", names(res))
+	}
+
+	if _, err := ListBranches(cfg, BranchListOptions{RepoPath: repo, Sort: "size"}); err == nil {
+		t.Fatalf("expected unsupported sort to fail")
+	}
+}
+
+func TestListBranchesMergedFindsSquashMergesAndLooksUpPRsPerBranch(t *testing.T) {
+	var heads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		head := r.URL.Query().Get("head")
+		heads = append(heads, head)
+		if head == "acme:feature/squashed" {
+			fmt.Fprint(w, `[{"number": 3, "state": "closed", "merged_at": "2024-01-01T00:00:00Z", "head": {"ref": "feature/squashed"}, "base": {"ref": "main"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+	t.Setenv("GITFLOW_TEST_TOKEN", "token")
+
+	repo := setupRepoForCleanup(t)
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/squashed")
+	commitFile(t, repo, "s.txt", "squash", "squash me")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/open", "main")
+	commitFile(t, repo, "o.txt", "open", "still open")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	runGitCleanup(t, repo, nil, "merge", "--squash", "feature/squashed")
+	runGitCleanup(t, repo, nil, "commit", "-m", "squashed feature")
+
+	cfg := config.Default()
+	cfg.Branches.MainBranch = "main"
+	cfg.Provider = config.ProviderConfig{
+		Type:     "github",
+		BaseURL:  server.URL,
+		TokenEnv: "GITFLOW_TEST_TOKEN",
+		Owner:    "acme",
+		Repo:     "repo",
+	}
+
+	res, err := ListBranches(cfg, BranchListOptions{RepoPath: repo, Merged: true, WithPRs: true})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if len(res.Branches) != 1 || res.Branches[0].Name != "feature/squashed" {
+		t.Fatalf("expected only feature/squashed, got %+v", res.Branches)
+	}
+	if res.Branches[0].PRNumber != 3 || res.Branches[0].PRState != "merged" {
+		t.Fatalf("expected merged PR #3, got #%d %s", res.Branches[0].PRNumber, res.Branches[0].PRState)
+	}
+	for _, head := range heads {
+		if head == "" {
+			t.Fatalf("expected pull requests to be looked up per branch, got %v", heads)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+// DescribeBranchOptions defines inputs for editing branch metadata. Nil
+// fields are left unchanged.
+type DescribeBranchOptions struct {
+	RepoPath string
+	Branch   string
+
+	Description *string
+	Issue       *string
+	Parent      *string
+}
+
+// DescribeBranchResult reports the stored branch metadata.
+type DescribeBranchResult struct {
+	Branch  string
+	Meta    git.BranchMeta
+	Changed bool
+}
+
+// PRDefaults holds the title and body a pull request for a branch starts with.
+type PRDefaults struct {
+	Branch      string
+	Title       string
+	Description string
+}
+
+var numericIssue = regexp.MustCompile(`^#?[0-9]+$`)
+
+// DescribeBranch updates the metadata stored for a branch, or the current
+// branch when none is given. With no fields set it only reads the metadata.
+func DescribeBranch(cfg *config.Config, opts DescribeBranchOptions) (*DescribeBranchResult, error) {
+	if strings.TrimSpace(opts.RepoPath) == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := strings.TrimSpace(opts.Branch)
+	if branch == "" {
+		branch, err = client.CurrentBranch()
+		if err != nil {
+			return nil, err
+		}
+	}
+	exists, err := client.BranchExists("refs/heads/" + branch)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("branch %s not found", branch)
+	}
+
+	meta, err := client.BranchMeta(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	before := meta
+	if opts.Description != nil {
+		meta.Description = strings.TrimSpace(*opts.Description)
+	}
+	if opts.Issue != nil {
+		meta.Issue = strings.TrimSpace(*opts.Issue)
+	}
+	if opts.Parent != nil {
+		meta.Parent = strings.TrimSpace(*opts.Parent)
+	}
+
+	res := &DescribeBranchResult{Branch: branch, Meta: meta}
+	if meta == before {
+		return res, nil
+	}
+	if err := client.SetBranchMeta(branch, meta); err != nil {
+		return nil, err
+	}
+	res.Changed = true
+	return res, nil
+}
+
+// DefaultPRContent returns the title and body pr create uses for the current
+// branch when none are given.
+func DefaultPRContent(cfg *config.Config, repoPath string) (*PRDefaults, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	client, err := git.NewClient(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := client.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	return prDefaults(cfg, client, branch), nil
+}
+
+// prDefaults prefers the branch description for the title and body, links
+// the recorded issue and falls back to a title derived from the branch name.
+func prDefaults(cfg *config.Config, client *git.Client, branch string) *PRDefaults {
+	meta, _ := client.BranchMeta(branch)
+
+	d := &PRDefaults{Branch: branch, Title: meta.Title(), Description: meta.Body()}
+	if d.Title == "" {
+		d.Title = defaultTitleFromBranch(cfg, branch)
+	}
+
+	issue := meta.Issue
+	if issue == "" {
+		if parts, ok := parseBranchName(cfg, branch); ok {
+			issue = parts.Issue
+		}
+	}
+	if issue != "" {
+		ref := issue
+		if numericIssue.MatchString(issue) {
+			ref = "#" + strings.TrimPrefix(issue, "#")
+		}
+		if d.Description != "" {
+			d.Description += "\n\n"
+		}
+		d.Description += "Refs " + ref
+	}
+	return d
+}
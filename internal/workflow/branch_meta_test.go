@@ -0,0 +1,83 @@
+package workflow
+
+import (
+	"testing"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+func TestStartRecordsMetadataUsedByListAndPR(t *testing.T) {
+	_, repo := setupOriginAndClone(t)
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Start.AutoPush = false
+
+	res, err := Start(cfg, StartOptions{
+		Kind:     "feature",
+		RepoPath: repo,
+		Remote:   "origin",
+		Name:     "user auth",
+		Issue:    "42",
+	})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	client, _ := git.NewClient(repo)
+	meta, err := client.BranchMeta(res.NewBranch)
+	if err != nil {
+		t.Fatalf("BranchMeta: %v", err)
+	}
+	if meta.Description != "user auth" || meta.Issue != "42" || meta.Parent != "main" || meta.Created.IsZero() {
+		t.Fatalf("unexpected metadata %+v", meta)
+	}
+
+	list, err := ListBranches(cfg, BranchListOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	found := false
+	for _, b := range list.Branches {
+		if b.Name == res.NewBranch {
+			found = b.Description == "user auth" && b.Issue == "42"
+		}
+	}
+	if !found {
+		t.Fatalf("expected branch list to include metadata")
+	}
+
+	desc := "Add user authentication\n\nSessions are stored server side."
+	out, err := DescribeBranch(cfg, DescribeBranchOptions{RepoPath: repo, Description: &desc})
+	if err != nil {
+		t.Fatalf("DescribeBranch: %v", err)
+	}
+	if !out.Changed || out.Meta.Issue != "42" {
+		t.Fatalf("expected description change to keep issue, got %+v", out)
+	}
+
+	d, err := DefaultPRContent(cfg, repo)
+	if err != nil {
+		t.Fatalf("DefaultPRContent: %v", err)
+	}
+	if d.Title != "Add user authentication" {
+		t.Fatalf("unexpected PR title %q", d.Title)
+	}
+	if d.Description != "Sessions are stored server side.\n\nRefs #42" {
+		t.Fatalf("unexpected PR body %q", d.Description)
+	}
+}
+
+func TestDefaultPRContentFallsBackToBranchName(t *testing.T) {
+	repo := setupCommitRepo(t)
+	runGitCommitTest(t, repo, "checkout", "-b", "feature/fix-login")
+
+	d, err := DefaultPRContent(config.Default(), repo)
+	if err != nil {
+		t.Fatalf("DefaultPRContent: %v", err)
+	}
+	if d.Title != "Fix Login" || d.Description != "" {
+		t.Fatalf("unexpected defaults %+v", d)
+	}
+}
@@ -0,0 +1,265 @@
+package workflow
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+// branchType is a resolved branch type: one of the built-in feature, bugfix
+// and hotfix kinds or an entry from branches.types.
+type branchType struct {
+	Name     string
+	Template string
+	Base     string
+}
+
+// branchNameData holds the values available to branch name templates.
+type branchNameData struct {
+	User  string
+	Type  string
+	Issue string
+	Slug  string
+}
+
+// branchNameParts is what parseBranchName recovers from a branch name.
+type branchNameParts struct {
+	Type  string
+	Issue string
+	Slug  string
+}
+
+var (
+	templateField = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+	issueField    = regexp.MustCompile(`\{\{\s*\.Issue\s*\}\}[-_./]?`)
+	issueChars    = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	repeatedSlash = regexp.MustCompile(`/+`)
+)
+
+// issuePattern is what an issue looks like when reading it back from a name:
+// a number, optionally with a tracker key such as PROJ-123.
+const issuePattern = `(?:[A-Za-z][A-Za-z0-9]*-)?[0-9]+`
+
+func startBaseBranch(cfg *config.Config) string {
+	base := cfg.Workflows.Start.BaseBranch
+	if base == "" {
+		base = cfg.Branches.MainBranch
+	}
+	if base == "" {
+		base = "main"
+	}
+	return base
+}
+
+// branchTypes lists configured branch types followed by the built-in kinds
+// they do not override.
+func branchTypes(cfg *config.Config) []branchType {
+	base := startBaseBranch(cfg)
+
+	var types []branchType
+	seen := make(map[string]bool)
+	for _, t := range cfg.Branches.Types {
+		name := strings.TrimSpace(t.Name)
+		if name == "" || seen[name] {
+			continue
+		}
+		bt := branchType{Name: name, Template: t.Template, Base: strings.TrimSpace(t.BaseBranch)}
+		if bt.Template == "" {
+			bt.Template = config.DefaultBranchTemplate
+		}
+		if bt.Base == "" {
+			bt.Base = base
+		}
+		types = append(types, bt)
+		seen[name] = true
+	}
+
+	builtin := []struct{ name, prefix string }{
+		{"feature", cfg.Branches.FeaturePrefix},
+		{"bugfix", cfg.Branches.BugfixPrefix},
+		{"hotfix", cfg.Branches.HotfixPrefix},
+	}
+	for _, b := range builtin {
+		if seen[b.name] {
+			continue
+		}
+		prefix := b.prefix
+		if prefix == "" {
+			prefix = b.name + "/"
+		}
+		types = append(types, branchType{Name: b.name, Template: prefix + "{{.Slug}}", Base: base})
+	}
+	return types
+}
+
+// findBranchType resolves a type name, defaulting to feature.
+func findBranchType(cfg *config.Config, name string) (branchType, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "feature"
+	}
+
+	types := branchTypes(cfg)
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		if t.Name == name {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	return branchType{}, fmt.Errorf("unknown branch type %q, expected one of: %s", name, strings.Join(names, ", "))
+}
+
+// buildBranchName renders the type template, shortening the slug to honour
+// branches.max_length and checking the result against branches.name_pattern.
+func buildBranchName(cfg *config.Config, client *git.Client, t branchType, issue string, name string) (string, error) {
+	data := branchNameData{
+		Type:  t.Name,
+		Issue: issueChars.ReplaceAllString(strings.TrimPrefix(strings.TrimSpace(issue), "#"), "-"),
+		Slug:  slugify(name),
+	}
+	if strings.Contains(t.Template, ".User") {
+		user, _ := client.ConfigValue("user.name")
+		data.User = slugify(user)
+		if strings.TrimSpace(user) == "" {
+			return "", fmt.Errorf("branch type %s uses {{.User}} but git user.name is not set", t.Name)
+		}
+	}
+
+	text := t.Template
+	if data.Issue == "" {
+		text = issueField.ReplaceAllString(text, "")
+	}
+	tmpl, err := template.New(t.Name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", ConfigError{Err: fmt.Errorf("branch type %s: %w", t.Name, err)}
+	}
+
+	branch, err := renderBranchName(tmpl, data)
+	if err != nil {
+		return "", err
+	}
+
+	if max := cfg.Branches.MaxLength; max > 0 && len(branch) > max {
+		keep := len(data.Slug) - (len(branch) - max)
+		if keep <= 0 {
+			return "", fmt.Errorf("branch name %s exceeds branches.max_length %d even without a slug", branch, max)
+		}
+		data.Slug = shortenSlug(data.Slug, keep)
+		if branch, err = renderBranchName(tmpl, data); err != nil {
+			return "", err
+		}
+	}
+
+	if pattern := strings.TrimSpace(cfg.Branches.NamePattern); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", ConfigError{Err: fmt.Errorf("branches.name_pattern: %w", err)}
+		}
+		if !re.MatchString(branch) {
+			return "", fmt.Errorf("branch name %s does not match branches.name_pattern %s", branch, pattern)
+		}
+	}
+	return branch, nil
+}
+
+func renderBranchName(tmpl *template.Template, data branchNameData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", ConfigError{Err: fmt.Errorf("branch type %s: %w", tmpl.Name(), err)}
+	}
+	name := repeatedSlash.ReplaceAllString(buf.String(), "/")
+	return strings.Trim(name, "/-_."), nil
+}
+
+// shortenSlug cuts a slug to at most n bytes, preferring a word boundary.
+func shortenSlug(slug string, n int) string {
+	if len(slug) <= n {
+		return slug
+	}
+	cut := slug[:n]
+	if i := strings.LastIndex(cut, "-"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.Trim(cut, "-")
+}
+
+// parseBranchName matches a branch against the configured type templates
+// and recovers its type, issue and slug.
+func parseBranchName(cfg *config.Config, branch string) (branchNameParts, bool) {
+	for _, t := range branchTypes(cfg) {
+		variants := []string{t.Template}
+		if stripped := issueField.ReplaceAllString(t.Template, ""); stripped != t.Template {
+			variants = append(variants, stripped)
+		}
+		for _, v := range variants {
+			re, ok := templateRegexp(v, t.Name)
+			if !ok {
+				continue
+			}
+			m := re.FindStringSubmatch(strings.Trim(branch, "/"))
+			if m == nil {
+				continue
+			}
+			parts := branchNameParts{Type: t.Name}
+			for i, name := range re.SubexpNames() {
+				switch name {
+				case "issue":
+					parts.Issue = m[i]
+				case "slug":
+					parts.Slug = m[i]
+				}
+			}
+			return parts, true
+		}
+	}
+	return branchNameParts{}, false
+}
+
+// templateRegexp turns a template made of plain {{.Field}} actions into a
+// regexp. Templates using anything else cannot be parsed back.
+func templateRegexp(text string, typeName string) (*regexp.Regexp, bool) {
+	var b strings.Builder
+	b.WriteString("^")
+	last := 0
+	for _, m := range templateField.FindAllStringSubmatchIndex(text, -1) {
+		literal := text[last:m[0]]
+		if last == 0 {
+			literal = strings.TrimLeft(literal, "/-_.")
+		}
+		if strings.Contains(literal, "{{") {
+			return nil, false
+		}
+		b.WriteString(regexp.QuoteMeta(literal))
+		switch text[m[2]:m[3]] {
+		case "User":
+			b.WriteString(`[^/]+`)
+		case "Type":
+			b.WriteString(regexp.QuoteMeta(typeName))
+		case "Issue":
+			b.WriteString(`(?P<issue>` + issuePattern + `)`)
+		case "Slug":
+			b.WriteString(`(?P<slug>.+)`)
+		default:
+			return nil, false
+		}
+		last = m[1]
+	}
+	tail := text[last:]
+	if strings.Contains(tail, "{{") {
+		return nil, false
+	}
+	b.WriteString(regexp.QuoteMeta(strings.Trim(tail, "/-_.")))
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, false
+	}
+	return re, true
+}
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+func branchTypeConfig() *config.Config {
+	cfg := config.Default()
+	cfg.Branches.Types = []config.BranchType{
+		{Name: "spike", Template: "{{.User}}/{{.Type}}/{{.Issue}}-{{.Slug}}"},
+		{Name: "docs"},
+		{Name: "release", Template: "release/{{.Slug}}", BaseBranch: "develop"},
+	}
+	return cfg
+}
+
+func TestBuildBranchNameFromTemplates(t *testing.T) {
+	repo := setupCommitRepo(t)
+	client, _ := git.NewClient(repo)
+	cfg := branchTypeConfig()
+
+	cases := []struct {
+		kind, issue, name, want string
+	}{
+		{"spike", "#42", "Cache Layer", "test-user/spike/42-cache-layer"},
+		{"spike", "", "Cache Layer", "test-user/spike/cache-layer"},
+		{"docs", "", "Install guide", "docs/install-guide"},
+		{"feature", "", "user auth", "feature/user-auth"},
+	}
+	for _, c := range cases {
+		kind, err := findBranchType(cfg, c.kind)
+		if err != nil {
+			t.Fatalf("findBranchType(%s): %v", c.kind, err)
+		}
+		got, err := buildBranchName(cfg, client, kind, c.issue, c.name)
+		if err != nil {
+			t.Fatalf("buildBranchName(%s): %v", c.kind, err)
+		}
+		if got != c.want {
+			t.Fatalf("buildBranchName(%s, %q) = %s, want %s", c.kind, c.name, got, c.want)
+		}
+	}
+
+	if _, err := findBranchType(cfg, "chore"); err == nil || !strings.Contains(err.Error(), "spike") {
+		t.Fatalf("expected unknown type error listing types, got %v", err)
+	}
+}
+
+func TestBuildBranchNameHonoursMaxLengthAndPattern(t *testing.T) {
+	repo := setupCommitRepo(t)
+	client, _ := git.NewClient(repo)
+	cfg := config.Default()
+	cfg.Branches.MaxLength = 24
+
+	kind, _ := findBranchType(cfg, "feature")
+	got, err := buildBranchName(cfg, client, kind, "", "add a very long branch description here")
+	if err != nil {
+		t.Fatalf("buildBranchName: %v", err)
+	}
+	if got != "feature/add-a-very-long" {
+		t.Fatalf("unexpected shortened name %s", got)
+	}
+
+	cfg.Branches.NamePattern = `^feature/[A-Z]+-[0-9]+`
+	if _, err := buildBranchName(cfg, client, kind, "", "no ticket"); err == nil {
+		t.Fatalf("expected name_pattern to reject branch")
+	}
+}
+
+func TestDefaultTitleStripsConfiguredPrefixes(t *testing.T) {
+	cfg := branchTypeConfig()
+
+	cases := map[string]string{
+		"jane/spike/PROJ-7-cache-layer": "Cache Layer",
+		"jane/spike/cache-layer":        "Cache Layer",
+		"docs/install_guide":            "Install Guide",
+		"feature/user-auth":             "User Auth",
+		"release/2.0":                   "2.0",
+	}
+	for branch, want := range cases {
+		if got := defaultTitleFromBranch(cfg, branch); got != want {
+			t.Fatalf("defaultTitleFromBranch(%s) = %q, want %q", branch, got, want)
+		}
+	}
+
+	parts, ok := parseBranchName(cfg, "jane/spike/PROJ-7-cache-layer")
+	if !ok || parts.Type != "spike" || parts.Issue != "PROJ-7" {
+		t.Fatalf("unexpected parse %+v", parts)
+	}
+}
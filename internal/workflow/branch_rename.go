@@ -0,0 +1,185 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+	"gitflow/internal/provider"
+)
+
+// BranchRenameOptions defines inputs for renaming a branch.
+type BranchRenameOptions struct {
+	RepoPath string
+	Old      string
+	New      string
+	Remote   string
+}
+
+// BranchRenameResult reports what a rename changed.
+type BranchRenameResult struct {
+	Old    string
+	New    string
+	Remote string
+
+	// RemoteRenamed is set when the branch existed on the remote and now
+	// exists there under the new name.
+	RemoteRenamed bool
+	// Retargeted is set when the provider renamed the remote branch and
+	// moved its pull requests along with it.
+	Retargeted bool
+	Upstream   string
+
+	// Children are local branches whose recorded parent was updated.
+	Children []string
+	Warnings []string
+}
+
+// RenameBranch renames a branch locally and on the remote, keeping its
+// upstream, metadata and pull requests attached. Failing remote steps roll the
+// local rename back so the branch is never left half renamed.
+func RenameBranch(cfg *config.Config, opts BranchRenameOptions) (*BranchRenameResult, error) {
+	if strings.TrimSpace(opts.RepoPath) == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+	oldName := strings.TrimSpace(opts.Old)
+	newName := strings.TrimSpace(opts.New)
+	if oldName == "" || newName == "" {
+		return nil, fmt.Errorf("old and new branch names are required")
+	}
+	if oldName == newName {
+		return nil, fmt.Errorf("branch is already named %s", newName)
+	}
+	if opts.Remote == "" {
+		opts.Remote = "origin"
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.CheckBranchName(newName); err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BranchExists("refs/heads/" + oldName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("branch %s not found", oldName)
+	}
+	if exists, err := client.BranchExists("refs/heads/" + newName); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, fmt.Errorf("branch %s already exists", newName)
+	}
+	matcher, err := cfg.ProtectedMatcher()
+	if err != nil {
+		return nil, ConfigError{Err: err}
+	}
+	if pattern, ok := matcher.Match(oldName); ok {
+		return nil, fmt.Errorf("refusing to rename protected branch %s (matches %q)", oldName, pattern)
+	}
+
+	res := &BranchRenameResult{Old: oldName, New: newName, Remote: opts.Remote}
+
+	onRemote := false
+	hasRemote, err := client.HasRemote(opts.Remote)
+	if err != nil {
+		return nil, err
+	}
+	if hasRemote {
+		if err := client.Fetch(opts.Remote); err != nil {
+			return nil, err
+		}
+		remoteRef := "refs/remotes/" + opts.Remote + "/"
+		if onRemote, err = client.BranchExists(remoteRef + oldName); err != nil {
+			return nil, err
+		}
+		if taken, err := client.BranchExists(remoteRef + newName); err != nil {
+			return nil, err
+		} else if taken {
+			return nil, fmt.Errorf("branch %s already exists on %s", newName, opts.Remote)
+		}
+	}
+
+	// Resolve the provider up front: a misconfigured token must not leave the
+	// remote branch deleted and its pull request closed.
+	var renamer provider.BranchRenamer
+	if onRemote && provider.Enabled(cfg) {
+		pcfg, err := provider.FromAppConfig(cfg)
+		if err != nil {
+			return nil, ProviderError{Err: err}
+		}
+		p, err := provider.New(pcfg)
+		if err != nil {
+			return nil, ProviderError{Err: err}
+		}
+		if r, ok := p.(provider.BranchRenamer); ok {
+			renamer = r
+		} else {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("%s cannot retarget pull requests; reopen any pull request from %s against %s", pcfg.Type, oldName, newName))
+		}
+	} else if onRemote {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("no provider configured; pull requests from %s were not retargeted", oldName))
+	}
+
+	if err := client.RenameBranch(oldName, newName); err != nil {
+		return nil, err
+	}
+
+	if onRemote {
+		if err := renameRemote(client, renamer, opts.Remote, oldName, newName); err != nil {
+			if rbErr := client.RenameBranch(newName, oldName); rbErr != nil {
+				return nil, fmt.Errorf("%w (restoring %s also failed: %v)", err, oldName, rbErr)
+			}
+			return nil, err
+		}
+		res.RemoteRenamed = true
+		res.Retargeted = renamer != nil
+
+		if err := client.SetUpstream(newName, opts.Remote, newName); err != nil {
+			return nil, err
+		}
+		res.Upstream = opts.Remote + "/" + newName
+	}
+
+	metas, err := client.AllBranchMeta()
+	if err != nil {
+		return nil, err
+	}
+	for name, meta := range metas {
+		if meta.Parent != oldName {
+			continue
+		}
+		meta.Parent = newName
+		if err := client.SetBranchMeta(name, meta); err != nil {
+			return nil, err
+		}
+		res.Children = append(res.Children, name)
+	}
+	sort.Strings(res.Children)
+
+	return res, nil
+}
+
+// renameRemote renames the remote branch through the provider when it can,
+// otherwise pushes the new name and deletes the old one in one atomic push.
+func renameRemote(client *git.Client, renamer provider.BranchRenamer, remote, oldName, newName string) error {
+	if renamer == nil {
+		return client.PushRename(remote, oldName, newName)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	if err := renamer.RenameBranch(ctx, oldName, newName); err != nil {
+		return ProviderError{Err: err}
+	}
+	return client.FetchPrune(remote)
+}
@@ -0,0 +1,156 @@
+package workflow
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestRenameBranchPushesNewNameAndDeletesOld(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	origin := filepath.Join(filepath.Dir(repo), "origin.git")
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/tpyo")
+	commitFile(t, repo, "t.txt", "t", "typo work")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/tpyo")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/child")
+
+	client, err := git.NewClient(repo)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if err := client.SetBranchMeta("feature/child", git.BranchMeta{Parent: "feature/tpyo"}); err != nil {
+		t.Fatalf("SetBranchMeta: %v", err)
+	}
+
+	res, err := RenameBranch(config.Default(), BranchRenameOptions{
+		RepoPath: repo,
+		Old:      "feature/tpyo",
+		New:      "feature/typo",
+	})
+	if err != nil {
+		t.Fatalf("RenameBranch: %v", err)
+	}
+	if !res.RemoteRenamed || res.Retargeted {
+		t.Fatalf("expected a plain push rename, got %+v", res)
+	}
+	if len(res.Warnings) != 1 || !strings.Contains(res.Warnings[0], "not retargeted") {
+		t.Fatalf("expected a retarget warning, got %v", res.Warnings)
+	}
+
+	heads := gitOutput(t, origin, "for-each-ref", "--format=%(refname:short)", "refs/heads/")
+	if !strings.Contains(heads, "feature/typo") || strings.Contains(heads, "feature/tpyo") {
+		t.Fatalf("unexpected origin branches:\n%s", heads)
+	}
+	if got := gitOutput(t, repo, "rev-parse", "--abbrev-ref", "feature/typo@{upstream}"); got != "origin/feature/typo" {
+		t.Fatalf("upstream = %q", got)
+	}
+
+	meta, err := client.BranchMeta("feature/child")
+	if err != nil {
+		t.Fatalf("BranchMeta: %v", err)
+	}
+	if meta.Parent != "feature/typo" || len(res.Children) != 1 {
+		t.Fatalf("expected child parent to follow the rename, got %q (%v)", meta.Parent, res.Children)
+	}
+}
+
+func TestRenameBranchUsesProviderRename(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	origin := filepath.Join(filepath.Dir(repo), "origin.git")
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/tpyo")
+	commitFile(t, repo, "t.txt", "t", "typo work")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/tpyo")
+
+	var renamed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.EscapedPath() != "/repos/acme/repo/branches/feature%2Ftpyo/rename" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Rename on the bare origin as the hosting service would.
+		cmd := exec.Command("git", "branch", "-m", "feature/tpyo", "feature/typo")
+		cmd.Dir = origin
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("rename on origin: %v %s", err, out)
+		}
+		renamed = true
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"name": "feature/typo"}`))
+	}))
+	defer server.Close()
+	t.Setenv("GITFLOW_TEST_TOKEN", "token")
+
+	cfg := config.Default()
+	cfg.Provider = config.ProviderConfig{
+		Type:     "github",
+		BaseURL:  server.URL,
+		TokenEnv: "GITFLOW_TEST_TOKEN",
+		Owner:    "acme",
+		Repo:     "repo",
+	}
+
+	res, err := RenameBranch(cfg, BranchRenameOptions{RepoPath: repo, Old: "feature/tpyo", New: "feature/typo"})
+	if err != nil {
+		t.Fatalf("RenameBranch: %v", err)
+	}
+	if !renamed || !res.Retargeted || len(res.Warnings) != 0 {
+		t.Fatalf("expected provider rename without warnings, got %+v", res)
+	}
+	if got := gitOutput(t, repo, "rev-parse", "--abbrev-ref", "feature/typo@{upstream}"); got != "origin/feature/typo" {
+		t.Fatalf("upstream = %q", got)
+	}
+	if out := gitOutput(t, repo, "branch", "-r", "--list", "origin/feature/tpyo"); out != "" {
+		t.Fatalf("expected old remote-tracking branch to be pruned, got %q", out)
+	}
+}
+
+func TestRenameBranchRollsBackWhenProviderFails(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/tpyo")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/tpyo")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+	t.Setenv("GITFLOW_TEST_TOKEN", "token")
+
+	cfg := config.Default()
+	cfg.Provider = config.ProviderConfig{
+		Type:     "github",
+		BaseURL:  server.URL,
+		TokenEnv: "GITFLOW_TEST_TOKEN",
+		Owner:    "acme",
+		Repo:     "repo",
+	}
+
+	if _, err := RenameBranch(cfg, BranchRenameOptions{RepoPath: repo, Old: "feature/tpyo", New: "feature/typo"}); err == nil {
+		t.Fatalf("expected provider failure")
+	}
+	if got := gitOutput(t, repo, "branch", "--show-current"); got != "feature/tpyo" {
+		t.Fatalf("expected local rename to be rolled back, current branch is %q", got)
+	}
+}
@@ -7,35 +7,61 @@ import (
 
 	"gitflow/internal/config"
 	"gitflow/internal/git"
+	"gitflow/pkg/types"
 )
 
 // CleanupOptions defines inputs for cleanup.
 type CleanupOptions struct {
 	RepoPath     string
 	Remote       string
+	Mode         string
 	Yes          bool
 	All          bool
 	AgeThreshold int
 	DeleteRemote bool
+	Force        bool
 	Selected     []string
 }
 
+const (
+	// CleanupLocal finds merged or stale local branches.
+	CleanupLocal = "local"
+	// CleanupGone finds local branches whose upstream was deleted.
+	CleanupGone = "gone"
+	// CleanupRemote finds merged or stale branches on the remote.
+	CleanupRemote = "remote"
+)
+
 // CleanupResult reports cleanup candidates and deletions.
 type CleanupResult struct {
+	Mode          string
+	RunID         string
 	BaseBranch    string
 	Current       string
 	Deleted       []string
 	RemoteDeleted []string
 	Candidates    []CandidateBranch
+	// Warnings reports checks that could not run, such as pull request
+	// lookups against an unreachable provider.
+	Warnings []string
 }
 
 // CandidateBranch describes a branch eligible for cleanup.
 type CandidateBranch struct {
-	Name    string
-	Reason  string
-	AgeDays int
-	Ahead   int
-	Behind  int
+	Name       string
+	Reason     string
+	Merged     bool
+	Author     string
+	LastCommit string
+	AgeDays    int
+	Ahead      int
+	Behind     int
+	// Unmerged marks branches whose commits have not landed on the base;
+	// deleting them requires Force.
+	Unmerged bool
+	// OnRemote reports that a local candidate also exists on the remote and
+	// would be deleted there with DeleteRemote.
+	OnRemote bool
 }
 
 // Cleanup identifies and optionally deletes stale branches.
@@ -47,13 +73,7 @@ func Cleanup(cfg *config.Config, opts CleanupOptions) (*CleanupResult, error) {
 		opts.Remote = "origin"
 	}
 
-	base := strings.TrimSpace(cfg.Workflows.Start.BaseBranch)
-	if base == "" {
-		base = strings.TrimSpace(cfg.Branches.MainBranch)
-	}
-	if base == "" {
-		base = "main"
-	}
+	base := startBaseBranch(cfg)
 
 	client, err := git.NewClient(opts.RepoPath)
 	if err != nil {
@@ -65,14 +85,9 @@ func Cleanup(cfg *config.Config, opts CleanupOptions) (*CleanupResult, error) {
 		return nil, err
 	}
 
-	protected := make(map[string]bool)
-	protected[base] = true
-	protected[current] = true
-	for _, p := range cfg.Workflows.Cleanup.ProtectedBranches {
-		v := strings.TrimSpace(p)
-		if v != "" {
-			protected[v] = true
-		}
+	protected, err := newProtectedSet(cfg, base, current)
+	if err != nil {
+		return nil, err
 	}
 
 	ageThreshold := opts.AgeThreshold
@@ -83,26 +98,190 @@ func Cleanup(cfg *config.Config, opts CleanupOptions) (*CleanupResult, error) {
 		ageThreshold = 0
 	}
 
+	mode := opts.Mode
+	if mode == "" {
+		mode = CleanupLocal
+	}
+
+	if mode == CleanupRemote {
+		return cleanupRemote(cfg, client, opts, base, current, protected, ageThreshold)
+	}
+	if mode != CleanupGone && mode != CleanupLocal {
+		return nil, fmt.Errorf("unsupported cleanup mode: %s", mode)
+	}
+
+	detector, err := newMergeDetector(client, base)
+	if err != nil {
+		return nil, err
+	}
+	detector.useProvider(cfg)
+
+	var candidates []CandidateBranch
+	if mode == CleanupGone {
+		candidates, err = goneCandidates(client, detector, opts.Remote, base, protected)
+	} else {
+		candidates, err = localCandidates(cfg, client, detector, opts, base, protected, ageThreshold)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := markUnmerged(client, base, "refs/heads/", candidates); err != nil {
+		return nil, err
+	}
+	if opts.DeleteRemote {
+		for i := range candidates {
+			candidates[i].OnRemote, _ = client.BranchExists("refs/remotes/" + opts.Remote + "/" + candidates[i].Name)
+		}
+	}
+	sortCandidates(candidates)
+
+	if len(candidates) == 0 {
+		return &CleanupResult{
+			Mode:       mode,
+			BaseBranch: base,
+			Current:    current,
+			Candidates: nil,
+			Warnings:   detector.warnings,
+		}, nil
+	}
+
+	toDelete := selectCandidates(candidates, opts)
+	if toDelete == nil {
+		return &CleanupResult{
+			Mode:       mode,
+			BaseBranch: base,
+			Current:    current,
+			Candidates: candidates,
+			Warnings:   detector.warnings,
+		}, nil
+	}
+
+	if !opts.Force {
+		if err := requireMerged(base, toDelete); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &CleanupResult{
+		Mode:       mode,
+		BaseBranch: base,
+		Current:    current,
+		Candidates: candidates,
+		Warnings:   detector.warnings,
+	}
+	run := newCleanupRun(mode, opts.Remote, base)
+
+	var remoteDelete []DeletedRef
+	for _, c := range toDelete {
+		commit, err := client.ResolveCommit("refs/heads/" + c.Name)
+		if err != nil {
+			return nil, journalAfter(client, run, res, err)
+		}
+		if opts.DeleteRemote && c.OnRemote {
+			if sha, err := client.ResolveCommit("refs/remotes/" + opts.Remote + "/" + c.Name); err == nil {
+				remoteDelete = append(remoteDelete, DeletedRef{Name: c.Name, Commit: sha})
+			}
+		}
+		ref := deletedLocalRef(client, c.Name, commit)
+		// Merge status was checked above, and squash or rebase merges are not
+		// ancestors of the base, so -d would refuse them.
+		if err := client.DeleteBranch(c.Name, true); err != nil {
+			return nil, journalAfter(client, run, res, err)
+		}
+		run.Local = append(run.Local, ref)
+		res.Deleted = append(res.Deleted, c.Name)
+	}
+
+	if len(remoteDelete) > 0 {
+		names := make([]string, 0, len(remoteDelete))
+		for _, r := range remoteDelete {
+			names = append(names, r.Name)
+		}
+		if err := client.DeleteRemoteBranches(opts.Remote, names...); err != nil {
+			return nil, journalAfter(client, run, res, err)
+		}
+		run.Remotes = remoteDelete
+		res.RemoteDeleted = names
+	}
+
+	if err := journalAfter(client, run, res, nil); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// markUnmerged flags candidates whose tips are not reachable from target
+// and were not detected as squash, rebase or PR merges.
+func markUnmerged(client *git.Client, target string, refPrefix string, candidates []CandidateBranch) error {
+	for i := range candidates {
+		if candidates[i].Merged {
+			continue
+		}
+		ok, err := client.IsAncestor(refPrefix+candidates[i].Name, target)
+		if err != nil {
+			return err
+		}
+		candidates[i].Unmerged = !ok
+	}
+	return nil
+}
+
+// requireMerged refuses to delete unmerged candidates unless the caller
+// forces it.
+func requireMerged(target string, candidates []CandidateBranch) error {
+	var unmerged []string
+	for _, c := range candidates {
+		if c.Unmerged {
+			unmerged = append(unmerged, c.Name)
+		}
+	}
+	if len(unmerged) > 0 {
+		return UnmergedBranchesError{Base: target, Branches: unmerged}
+	}
+	return nil
+}
+
+// journalAfter records whatever the run deleted, even when a later step
+// failed, so partial cleanups can still be undone.
+func journalAfter(client *git.Client, run *CleanupRun, res *CleanupResult, err error) error {
+	if jerr := recordCleanupRun(client, run); jerr != nil {
+		if err != nil {
+			return fmt.Errorf("%w (also failed to record undo journal: %v)", err, jerr)
+		}
+		return jerr
+	}
+	if !run.empty() {
+		res.RunID = run.ID
+	}
+	return err
+}
+
+func localCandidates(cfg *config.Config, client *git.Client, detector *mergeDetector, opts CleanupOptions, base string, protected protectedSet, ageThreshold int) ([]CandidateBranch, error) {
 	var candidates []CandidateBranch
 
 	if cfg.Workflows.Cleanup.MergedOnly && !opts.All {
-		merged, err := client.MergedBranches(base)
+		branches, err := client.ListLocalBranches(base)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, b := range merged {
-			if protected[b] {
+		for _, b := range branches {
+			if protected.has(b.Name) {
+				continue
+			}
+			reason := detector.detect(b.Name)
+			if reason == "" {
 				continue
 			}
-			age, _ := clientBranchAgeDays(client, b)
-			ahead, behind := clientAheadBehind(client, b, base)
 			candidates = append(candidates, CandidateBranch{
-				Name:    b,
-				Reason:  "merged",
-				AgeDays: age,
-				Ahead:   ahead,
-				Behind:  behind,
+				Name:       b.Name,
+				Reason:     reason,
+				Merged:     true,
+				LastCommit: b.LastCommitMsg,
+				AgeDays:    b.AgeDays,
+				Ahead:      b.Ahead,
+				Behind:     b.Behind,
 			})
 		}
 	} else {
@@ -112,7 +291,7 @@ func Cleanup(cfg *config.Config, opts CleanupOptions) (*CleanupResult, error) {
 		}
 
 		for _, b := range branches {
-			if protected[b.Name] {
+			if protected.has(b.Name) {
 				continue
 			}
 			if ageThreshold > 0 && b.AgeDays < ageThreshold {
@@ -120,140 +299,224 @@ func Cleanup(cfg *config.Config, opts CleanupOptions) (*CleanupResult, error) {
 			}
 
 			reason := "stale"
+			merged := false
 			if cfg.Workflows.Cleanup.MergedOnly {
-				mergedList, err := client.MergedBranches(base)
-				if err == nil {
-					for _, mb := range mergedList {
-						if mb == b.Name {
-							reason = "merged and stale"
-							break
-						}
-					}
+				if method := detector.detect(b.Name); method != "" {
+					reason = method + " and stale"
+					merged = true
 				}
 			}
 
 			candidates = append(candidates, CandidateBranch{
-				Name:    b.Name,
-				Reason:  reason,
-				AgeDays: b.AgeDays,
-				Ahead:   b.Ahead,
-				Behind:  b.Behind,
+				Name:       b.Name,
+				Reason:     reason,
+				Merged:     merged,
+				LastCommit: b.LastCommitMsg,
+				AgeDays:    b.AgeDays,
+				Ahead:      b.Ahead,
+				Behind:     b.Behind,
 			})
 		}
 	}
 
-	sort.Slice(candidates, func(i, j int) bool {
-		if candidates[i].Reason != candidates[j].Reason {
-			return candidates[i].Reason < candidates[j].Reason
+	return candidates, nil
+}
+
+func goneCandidates(client *git.Client, detector *mergeDetector, remote string, base string, protected protectedSet) ([]CandidateBranch, error) {
+	if err := client.FetchPrune(remote); err != nil {
+		return nil, err
+	}
+
+	gone, err := client.GoneBranches()
+	if err != nil {
+		return nil, err
+	}
+
+	branches, err := client.ListLocalBranches(base)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*types.Branch, len(branches))
+	for _, b := range branches {
+		byName[b.Name] = b
+	}
+
+	var candidates []CandidateBranch
+	for _, b := range gone {
+		info, ok := byName[b]
+		if !ok || protected.has(b) {
+			continue
 		}
-		if candidates[i].AgeDays != candidates[j].AgeDays {
-			return candidates[i].AgeDays > candidates[j].AgeDays
+		reason := "upstream gone"
+		merged := false
+		if method := detector.detect(b); method != "" {
+			reason += ", " + method
+			merged = true
 		}
-		return candidates[i].Name < candidates[j].Name
-	})
+		candidates = append(candidates, CandidateBranch{
+			Name:       b,
+			Reason:     reason,
+			Merged:     merged,
+			LastCommit: info.LastCommitMsg,
+			AgeDays:    info.AgeDays,
+			Ahead:      info.Ahead,
+			Behind:     info.Behind,
+		})
+	}
+	return candidates, nil
+}
 
-	if len(candidates) == 0 {
-		return &CleanupResult{
-			BaseBranch: base,
-			Current:    current,
-			Candidates: nil,
-		}, nil
+// cleanupRemote lists merged or stale branches on the remote and deletes the
+// selected ones in a single push when confirmed.
+func cleanupRemote(cfg *config.Config, client *git.Client, opts CleanupOptions, base string, current string, protected protectedSet, ageThreshold int) (*CleanupResult, error) {
+	if err := client.FetchPrune(opts.Remote); err != nil {
+		return nil, err
 	}
 
-	var toDelete []string
-	if opts.Yes {
-		allow := make(map[string]bool)
-		if len(opts.Selected) > 0 {
-			for _, s := range opts.Selected {
-				s = strings.TrimSpace(s)
-				if s != "" {
-					allow[s] = true
-				}
-			}
+	target := opts.Remote + "/" + base
+	branches, err := client.ListRemoteBranches(opts.Remote, target)
+	if err != nil {
+		return nil, err
+	}
+
+	detector, err := newRemoteMergeDetector(client, opts.Remote, base)
+	if err != nil {
+		return nil, err
+	}
+	detector.useProvider(cfg)
+
+	var candidates []CandidateBranch
+	for _, b := range branches {
+		if protected.has(b.Name) {
+			continue
 		}
 
-		for _, c := range candidates {
-			if len(allow) > 0 && !allow[c.Name] {
+		reason := detector.detect(b.Name)
+		merged := reason != ""
+		if !merged {
+			if ageThreshold <= 0 || b.AgeDays < ageThreshold {
 				continue
 			}
-			toDelete = append(toDelete, c.Name)
+			reason = "stale"
 		}
-	} else {
-		return &CleanupResult{
-			BaseBranch: base,
-			Current:    current,
-			Candidates: candidates,
-		}, nil
+
+		candidates = append(candidates, CandidateBranch{
+			Name:       b.Name,
+			Reason:     reason,
+			Merged:     merged,
+			Author:     b.Author,
+			LastCommit: b.LastCommitMsg,
+			AgeDays:    b.AgeDays,
+			Ahead:      b.Ahead,
+			Behind:     b.Behind,
+		})
+	}
+
+	if err := markUnmerged(client, target, "refs/remotes/"+opts.Remote+"/", candidates); err != nil {
+		return nil, err
 	}
+	sortCandidates(candidates)
 
-	var deleted []string
-	for _, b := range toDelete {
-		if err := client.DeleteBranch(b, false); err != nil {
+	res := &CleanupResult{
+		Mode:       CleanupRemote,
+		BaseBranch: base,
+		Current:    current,
+		Candidates: candidates,
+		Warnings:   detector.warnings,
+	}
+
+	toDelete := selectCandidates(candidates, opts)
+	if len(toDelete) == 0 {
+		return res, nil
+	}
+
+	if !opts.Force {
+		if err := requireMerged(target, toDelete); err != nil {
 			return nil, err
 		}
-		deleted = append(deleted, b)
 	}
 
-	var remoteDeleted []string
-	if opts.DeleteRemote {
-		for _, b := range deleted {
-			if err := client.DeleteRemoteBranch(opts.Remote, b); err != nil {
-				return nil, err
-			}
-			remoteDeleted = append(remoteDeleted, b)
+	run := newCleanupRun(CleanupRemote, opts.Remote, base)
+	names := make([]string, 0, len(toDelete))
+	for _, c := range toDelete {
+		commit, err := client.ResolveCommit("refs/remotes/" + opts.Remote + "/" + c.Name)
+		if err != nil {
+			return nil, err
 		}
+		run.Remotes = append(run.Remotes, DeletedRef{Name: c.Name, Commit: commit})
+		names = append(names, c.Name)
 	}
+	if err := client.DeleteRemoteBranches(opts.Remote, names...); err != nil {
+		return nil, err
+	}
+	res.RemoteDeleted = names
+	if err := journalAfter(client, run, res, nil); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
-	return &CleanupResult{
-		BaseBranch:    base,
-		Current:       current,
-		Deleted:       deleted,
-		RemoteDeleted: remoteDeleted,
-		Candidates:    candidates,
-	}, nil
+// protectedSet holds the branches cleanup must never delete: the base, the
+// current branch and anything matching the configured protected patterns.
+type protectedSet struct {
+	names   map[string]bool
+	matcher *config.BranchMatcher
 }
 
-func clientBranchAgeDays(c *git.Client, branch string) (int, error) {
-	out, err := c.Run("log", "-1", "--format=%ct", branch)
+func newProtectedSet(cfg *config.Config, base string, current string) (protectedSet, error) {
+	patterns := append([]string{}, cfg.Workflows.Cleanup.ProtectedBranches...)
+	patterns = append(patterns, cfg.Branches.Protected...)
+	matcher, err := config.NewBranchMatcher(patterns...)
 	if err != nil {
-		return 0, err
+		return protectedSet{}, ConfigError{Err: err}
 	}
+	return protectedSet{
+		names:   map[string]bool{base: true, current: true},
+		matcher: matcher,
+	}, nil
+}
 
-	sec, err := parseInt64(strings.TrimSpace(out))
-	if err != nil {
-		return 0, err
-	}
-	ageDays := int(secondsSince(sec) / 86400)
-	if ageDays < 0 {
-		ageDays = 0
+func (p protectedSet) has(name string) bool {
+	if p.names[name] {
+		return true
 	}
-	return ageDays, nil
+	_, ok := p.matcher.Match(name)
+	return ok
 }
 
-func clientAheadBehind(c *git.Client, branch string, base string) (int, int) {
-	a, b, err := cAheadBehind(c, branch, base)
-	if err != nil {
-		return 0, 0
-	}
-	return a, b
+func sortCandidates(candidates []CandidateBranch) {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Reason != candidates[j].Reason {
+			return candidates[i].Reason < candidates[j].Reason
+		}
+		if candidates[i].AgeDays != candidates[j].AgeDays {
+			return candidates[i].AgeDays > candidates[j].AgeDays
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
 }
 
-func cAheadBehind(c *git.Client, branch string, base string) (int, int, error) {
-	out, err := c.Run("rev-list", "--left-right", "--count", fmt.Sprintf("%s...%s", base, branch))
-	if err != nil {
-		return 0, 0, err
+// selectCandidates returns the candidates to delete, or nil when deletion
+// was not confirmed.
+func selectCandidates(candidates []CandidateBranch, opts CleanupOptions) []CandidateBranch {
+	if !opts.Yes {
+		return nil
 	}
-	fields := strings.Fields(strings.TrimSpace(out))
-	if len(fields) != 2 {
-		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", out)
-	}
-	behind, err := parseInt(fields[0])
-	if err != nil {
-		return 0, 0, err
+
+	allow := make(map[string]bool)
+	for _, s := range opts.Selected {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			allow[s] = true
+		}
 	}
-	ahead, err := parseInt(fields[1])
-	if err != nil {
-		return 0, 0, err
+
+	toDelete := []CandidateBranch{}
+	for _, c := range candidates {
+		if len(allow) > 0 && !allow[c.Name] {
+			continue
+		}
+		toDelete = append(toDelete, c)
 	}
-	return ahead, behind, nil
+	return toDelete
 }
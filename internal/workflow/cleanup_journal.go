@@ -0,0 +1,301 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gitflow/internal/git"
+)
+
+const cleanupJournalDir = "cleanup"
+
+// CleanupRun records the branches deleted by one cleanup so they can be restored.
+type CleanupRun struct {
+	ID       string       `json:"id"`
+	Mode     string       `json:"mode"`
+	Remote   string       `json:"remote"`
+	Base     string       `json:"base"`
+	At       time.Time    `json:"at"`
+	Local    []DeletedRef `json:"local,omitempty"`
+	Remotes  []DeletedRef `json:"remote_branches,omitempty"`
+	UndoneAt *time.Time   `json:"undone_at,omitempty"`
+}
+
+// DeletedRef is a deleted branch and the commit it pointed to. Local
+// branches also keep the upstream and description that deleting them drops.
+type DeletedRef struct {
+	Name           string `json:"name"`
+	Commit         string `json:"commit"`
+	UpstreamRemote string `json:"upstream_remote,omitempty"`
+	UpstreamMerge  string `json:"upstream_merge,omitempty"`
+	Description    string `json:"description,omitempty"`
+}
+
+// deletedLocalRef captures a local branch before it is deleted.
+func deletedLocalRef(client *git.Client, name string, commit string) DeletedRef {
+	ref := DeletedRef{Name: name, Commit: commit}
+	ref.UpstreamRemote, _ = client.ConfigValue("branch." + name + ".remote")
+	ref.UpstreamMerge, _ = client.ConfigValue("branch." + name + ".merge")
+	ref.Description, _ = client.ConfigValue("branch." + name + ".description")
+	return ref
+}
+
+// restoreBranchConfig puts back the upstream and description of a restored
+// local branch.
+func restoreBranchConfig(client *git.Client, ref DeletedRef) error {
+	values := []struct{ key, value string }{
+		{"remote", ref.UpstreamRemote},
+		{"merge", ref.UpstreamMerge},
+		{"description", ref.Description},
+	}
+	for _, v := range values {
+		if v.value == "" {
+			continue
+		}
+		if err := client.SetConfigValue("branch."+ref.Name+"."+v.key, v.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CleanupUndoOptions defines inputs for restoring a cleanup run.
+type CleanupUndoOptions struct {
+	RepoPath string
+	RunID    string
+}
+
+// CleanupUndoResult reports what was restored from a cleanup run.
+type CleanupUndoResult struct {
+	Run            CleanupRun
+	Restored       []string
+	RemoteRestored []string
+	Skipped        []UndoSkip
+	// Failed is set when a branch could not be restored. The run is then
+	// left open so undoing it again retries the failed branches.
+	Failed bool
+}
+
+// UndoSkip describes a branch that could not be restored.
+type UndoSkip struct {
+	Name   string
+	Remote bool
+	Reason string
+}
+
+func newCleanupRun(mode, remote, base string) *CleanupRun {
+	now := time.Now().UTC()
+	return &CleanupRun{
+		ID:     now.Format("20060102-150405"),
+		Mode:   mode,
+		Remote: remote,
+		Base:   base,
+		At:     now,
+	}
+}
+
+func (r *CleanupRun) empty() bool {
+	return len(r.Local) == 0 && len(r.Remotes) == 0
+}
+
+func cleanupJournalPath(client *git.Client) (string, error) {
+	dir, err := gitflowDir(client)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, cleanupJournalDir), nil
+}
+
+// recordCleanupRun writes the run to the journal, picking a unique id when
+// several runs land in the same second. Empty runs are not recorded.
+func recordCleanupRun(client *git.Client, run *CleanupRun) error {
+	if run == nil || run.empty() {
+		return nil
+	}
+	dir, err := cleanupJournalPath(client)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cleanup journal: %w", err)
+	}
+
+	id := run.ID
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, id+".json")); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", run.ID, i)
+	}
+	run.ID = id
+	return writeCleanupRun(dir, run)
+}
+
+func writeCleanupRun(dir string, run *CleanupRun) error {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cleanup journal: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, run.ID+".json"), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cleanup journal: %w", err)
+	}
+	return nil
+}
+
+func loadCleanupRuns(client *git.Client) (string, []CleanupRun, error) {
+	dir, err := cleanupJournalPath(client)
+	if err != nil {
+		return "", nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return dir, nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read cleanup journal: %w", err)
+	}
+
+	var runs []CleanupRun
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read cleanup journal: %w", err)
+		}
+		var run CleanupRun
+		if err := json.Unmarshal(data, &run); err != nil {
+			return "", nil, fmt.Errorf("failed to parse cleanup journal at %s: %w", path, err)
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		if !runs[i].At.Equal(runs[j].At) {
+			return runs[i].At.After(runs[j].At)
+		}
+		return runs[i].ID > runs[j].ID
+	})
+	return dir, runs, nil
+}
+
+// CleanupHistory lists recorded cleanup runs, newest first.
+func CleanupHistory(repoPath string) ([]CleanupRun, error) {
+	if strings.TrimSpace(repoPath) == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+	client, err := git.NewClient(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	_, runs, err := loadCleanupRuns(client)
+	return runs, err
+}
+
+// CleanupUndo recreates the branches deleted by a cleanup run. Without a run
+// id it restores the most recent run that has not been undone yet.
+func CleanupUndo(opts CleanupUndoOptions) (*CleanupUndoResult, error) {
+	if strings.TrimSpace(opts.RepoPath) == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, runs, err := loadCleanupRuns(client)
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.TrimSpace(opts.RunID)
+	var run *CleanupRun
+	for i := range runs {
+		if id == "" && runs[i].UndoneAt == nil || id != "" && runs[i].ID == id {
+			run = &runs[i]
+			break
+		}
+	}
+	if run == nil {
+		if id == "" {
+			return nil, fmt.Errorf("no cleanup runs to undo")
+		}
+		return nil, fmt.Errorf("cleanup run %s not found, see gitflow cleanup history", id)
+	}
+	if run.UndoneAt != nil {
+		return nil, fmt.Errorf("cleanup run %s was already undone at %s", run.ID, run.UndoneAt.Local().Format(time.RFC3339))
+	}
+
+	res := &CleanupUndoResult{}
+	for _, ref := range run.Local {
+		exists, _ := client.BranchExists("refs/heads/" + ref.Name)
+		if exists {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Reason: "branch already exists"})
+			continue
+		}
+		if _, err := client.ResolveCommit(ref.Commit); err != nil {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Reason: missingCommitReason(ref.Commit)})
+			continue
+		}
+		if err := client.CreateBranchAt(ref.Name, ref.Commit); err != nil {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Reason: err.Error()})
+			res.Failed = true
+			continue
+		}
+		if err := restoreBranchConfig(client, ref); err != nil {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Reason: "restored without its upstream or description: " + err.Error()})
+		}
+		res.Restored = append(res.Restored, ref.Name)
+	}
+
+	// Check the remote as it is now, not as of the last fetch.
+	var fetchErr error
+	if len(run.Remotes) > 0 {
+		fetchErr = client.FetchPrune(run.Remote)
+	}
+	for _, ref := range run.Remotes {
+		if fetchErr != nil {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Remote: true, Reason: "could not fetch " + run.Remote + ": " + fetchErr.Error()})
+			res.Failed = true
+			continue
+		}
+		exists, _ := client.BranchExists("refs/remotes/" + run.Remote + "/" + ref.Name)
+		if exists {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Remote: true, Reason: "branch already exists on " + run.Remote})
+			continue
+		}
+		if _, err := client.ResolveCommit(ref.Commit); err != nil {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Remote: true, Reason: missingCommitReason(ref.Commit)})
+			continue
+		}
+		if err := client.PushCommit(run.Remote, ref.Commit, ref.Name); err != nil {
+			res.Skipped = append(res.Skipped, UndoSkip{Name: ref.Name, Remote: true, Reason: err.Error()})
+			res.Failed = true
+			continue
+		}
+		res.RemoteRestored = append(res.RemoteRestored, ref.Name)
+	}
+
+	if !res.Failed {
+		now := time.Now().UTC()
+		run.UndoneAt = &now
+		if err := writeCleanupRun(dir, run); err != nil {
+			return nil, err
+		}
+	}
+	res.Run = *run
+	return res, nil
+}
+
+func missingCommitReason(commit string) string {
+	return fmt.Sprintf("commit %.7s is no longer in this repository, it was probably garbage collected", commit)
+}
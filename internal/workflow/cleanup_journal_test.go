@@ -0,0 +1,187 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+func TestCleanupUndoRestoresLocalAndRemoteBranches(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/done")
+	commitFile(t, repo, "d.txt", "d", "done work")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/done")
+	runGitCleanup(t, repo, nil, "config", "branch.feature/done.description", "Finish the work")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	runGitCleanup(t, repo, nil, "merge", "--no-ff", "feature/done", "-m", "merge done")
+
+	client, _ := git.NewClient(repo)
+	tip, err := client.ResolveCommit("feature/done")
+	if err != nil {
+		t.Fatalf("ResolveCommit: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Yes: true, DeleteRemote: true})
+	if err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+	if out.RunID == "" || len(out.Deleted) != 1 || len(out.RemoteDeleted) != 1 {
+		t.Fatalf("unexpected cleanup result %+v", out)
+	}
+
+	runs, err := CleanupHistory(repo)
+	if err != nil {
+		t.Fatalf("CleanupHistory: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != out.RunID {
+		t.Fatalf("expected one recorded run, got %+v", runs)
+	}
+	if len(runs[0].Local) != 1 || runs[0].Local[0].Commit != tip {
+		t.Fatalf("expected tip %s to be journaled, got %+v", tip, runs[0].Local)
+	}
+
+	undo, err := CleanupUndo(CleanupUndoOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("CleanupUndo: %v", err)
+	}
+	if len(undo.Restored) != 1 || len(undo.RemoteRestored) != 1 || len(undo.Skipped) != 0 {
+		t.Fatalf("unexpected undo result %+v", undo)
+	}
+
+	restored, err := client.ResolveCommit("feature/done")
+	if err != nil || restored != tip {
+		t.Fatalf("expected feature/done at %s, got %s (%v)", tip, restored, err)
+	}
+	heads, _ := client.Run("ls-remote", "--heads", "origin", "feature/done")
+	if !strings.HasPrefix(heads, tip) {
+		t.Fatalf("expected remote branch at %s, got %q", tip, heads)
+	}
+	if upstream := gitOutput(t, repo, "rev-parse", "--abbrev-ref", "feature/done@{upstream}"); upstream != "origin/feature/done" {
+		t.Fatalf("expected upstream to be restored, got %q", upstream)
+	}
+	if desc, _ := client.ConfigValue("branch.feature/done.description"); desc != "Finish the work" {
+		t.Fatalf("expected description to be restored, got %q", desc)
+	}
+
+	if _, err := CleanupUndo(CleanupUndoOptions{RepoPath: repo, RunID: out.RunID}); err == nil {
+		t.Fatalf("expected second undo of the same run to fail")
+	}
+}
+
+func TestCleanupUndoCanRetryFailedBranches(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/done")
+	commitFile(t, repo, "d.txt", "d", "done work")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/done")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	runGitCleanup(t, repo, nil, "merge", "--no-ff", "feature/done", "-m", "merge done")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	if _, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Yes: true, DeleteRemote: true}); err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+
+	originURL := gitOutput(t, repo, "remote", "get-url", "origin")
+	runGitCleanup(t, repo, nil, "remote", "set-url", "origin", originURL+"-missing")
+	undo, err := CleanupUndo(CleanupUndoOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("CleanupUndo: %v", err)
+	}
+	if !undo.Failed || undo.Run.UndoneAt != nil || len(undo.Restored) != 1 {
+		t.Fatalf("expected the remote restore to fail and the run to stay open, got %+v", undo)
+	}
+
+	runGitCleanup(t, repo, nil, "remote", "set-url", "origin", originURL)
+	retry, err := CleanupUndo(CleanupUndoOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("CleanupUndo retry: %v", err)
+	}
+	if retry.Failed || retry.Run.UndoneAt == nil || len(retry.RemoteRestored) != 1 {
+		t.Fatalf("expected the retry to restore the remote branch, got %+v", retry)
+	}
+}
+
+func TestCleanupUndoChecksRemoteAndMissingCommits(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	baseTip := gitOutput(t, repo, "rev-parse", "main")
+	client, _ := git.NewClient(repo)
+
+	// Someone recreated feature/back on the remote since the last fetch.
+	runGitCleanup(t, repo, nil, "push", "origin", baseTip+":refs/heads/feature/back")
+	runGitCleanup(t, repo, nil, "update-ref", "-d", "refs/remotes/origin/feature/back")
+
+	missing := strings.Repeat("ab", 20)
+	run := newCleanupRun(CleanupLocal, "origin", "main")
+	run.Local = []DeletedRef{{Name: "feature/gc", Commit: missing}}
+	run.Remotes = []DeletedRef{{Name: "feature/back", Commit: baseTip}, {Name: "feature/gc", Commit: missing}}
+	if err := recordCleanupRun(client, run); err != nil {
+		t.Fatalf("recordCleanupRun: %v", err)
+	}
+
+	undo, err := CleanupUndo(CleanupUndoOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("CleanupUndo: %v", err)
+	}
+	if undo.Failed || len(undo.Restored) != 0 || len(undo.RemoteRestored) != 0 || len(undo.Skipped) != 3 {
+		t.Fatalf("unexpected undo result %+v", undo)
+	}
+	reasons := make(map[string]string)
+	for _, s := range undo.Skipped {
+		key := s.Name
+		if s.Remote {
+			key = "origin/" + key
+		}
+		reasons[key] = s.Reason
+	}
+	if !strings.Contains(reasons["origin/feature/back"], "already exists") {
+		t.Fatalf("expected the recreated remote branch to be kept, got %q", reasons["origin/feature/back"])
+	}
+	for _, name := range []string{"feature/gc", "origin/feature/gc"} {
+		if !strings.Contains(reasons[name], "garbage collected") {
+			t.Fatalf("expected %s to be skipped as garbage collected, got %q", name, reasons[name])
+		}
+	}
+}
+
+func TestCleanupRequiresForceForUnmergedBranches(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/wip")
+	commitFile(t, repo, "w.txt", "w", "wip work")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Cleanup.AgeThresholdDays = 0
+
+	_, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, All: true, Yes: true})
+	var unmerged UnmergedBranchesError
+	if !errors.As(err, &unmerged) {
+		t.Fatalf("expected UnmergedBranchesError, got %v", err)
+	}
+	if len(unmerged.Branches) != 1 || unmerged.Branches[0] != "feature/wip" {
+		t.Fatalf("unexpected unmerged branches %v", unmerged.Branches)
+	}
+
+	client, _ := git.NewClient(repo)
+	if ok, _ := client.BranchExists("feature/wip"); !ok {
+		t.Fatalf("expected feature/wip to survive without --force")
+	}
+
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, All: true, Yes: true, Force: true})
+	if err != nil {
+		t.Fatalf("Cleanup with force: %v", err)
+	}
+	if len(out.Deleted) != 1 || out.Deleted[0] != "feature/wip" {
+		t.Fatalf("expected feature/wip to be deleted, got %v", out.Deleted)
+	}
+}
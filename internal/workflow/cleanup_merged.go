@@ -0,0 +1,163 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+	"gitflow/internal/provider"
+)
+
+const (
+	mergedByAncestry = "merged"
+	mergedBySquash   = "squash-merged"
+	mergedByRebase   = "rebase-merged"
+)
+
+// mergeDetector decides whether a branch has landed on the base and how.
+type mergeDetector struct {
+	client    *git.Client
+	base      string
+	refPrefix string
+	merged    map[string]bool
+	provider  provider.Provider
+	prs       map[string][]mergedPR
+	warnings  []string
+}
+
+// mergedPR is a merged pull request and the commit at its head.
+type mergedPR struct {
+	number int
+	head   string
+}
+
+func newMergeDetector(client *git.Client, base string) (*mergeDetector, error) {
+	merged, err := client.MergedBranches(base)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &mergeDetector{
+		client: client,
+		base:   base,
+		merged: make(map[string]bool),
+		prs:    make(map[string][]mergedPR),
+	}
+	for _, b := range merged {
+		d.merged[b] = true
+	}
+	return d, nil
+}
+
+// newRemoteMergeDetector checks remote-tracking branches of remote against
+// the remote-tracking base branch.
+func newRemoteMergeDetector(client *git.Client, remote string, base string) (*mergeDetector, error) {
+	target := remote + "/" + base
+	merged, err := client.MergedRemoteBranches(remote, target)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &mergeDetector{
+		client:    client,
+		base:      target,
+		refPrefix: remote + "/",
+		merged:    make(map[string]bool),
+		prs:       make(map[string][]mergedPR),
+	}
+	for _, b := range merged {
+		d.merged[b] = true
+	}
+	return d, nil
+}
+
+// detect returns how branch was merged into the base, or an empty string
+// when no merge was found. Ancestry is checked first, then patch equivalence
+// for rebase merges, then a squash probe, then merged pull requests.
+func (d *mergeDetector) detect(branch string) string {
+	if d.merged[branch] {
+		return mergedByAncestry
+	}
+	ref := d.refPrefix + branch
+	if ok, err := d.client.CherryEquivalent(d.base, ref); err == nil && ok {
+		return mergedByRebase
+	}
+	if ok, err := d.client.SquashMerged(d.base, ref); err == nil && ok {
+		return mergedBySquash
+	}
+	if number, ok := d.mergedPR(branch, ref); ok {
+		return fmt.Sprintf("merged (PR #%d)", number)
+	}
+	return ""
+}
+
+// mergedPR finds a merged pull request from branch that contains its tip.
+// A branch name reused after its pull request merged has new commits, so
+// matching on the name alone is not enough.
+func (d *mergeDetector) mergedPR(branch, ref string) (int, bool) {
+	prs := d.branchPRs(branch)
+	if len(prs) == 0 {
+		return 0, false
+	}
+	tip, err := d.client.ResolveCommit(ref)
+	if err != nil {
+		return 0, false
+	}
+	for _, pr := range prs {
+		if pr.head == "" {
+			continue
+		}
+		if pr.head == tip {
+			return pr.number, true
+		}
+		if ok, err := d.client.IsAncestor(tip, pr.head); err == nil && ok {
+			return pr.number, true
+		}
+	}
+	return 0, false
+}
+
+// useProvider adds merged pull requests to the checks. Detection still works
+// from git data when no provider is configured; a provider that cannot be set
+// up is reported as a warning.
+func (d *mergeDetector) useProvider(cfg *config.Config) {
+	if !provider.Enabled(cfg) {
+		return
+	}
+	pcfg, err := provider.FromAppConfig(cfg)
+	if err == nil {
+		d.provider, err = provider.New(pcfg)
+	}
+	if err != nil {
+		d.warnings = append(d.warnings, fmt.Sprintf("pull requests were not checked: %v", err))
+	}
+}
+
+// branchPRs returns the merged pull requests from branch, asking the
+// provider once per branch. After a failed lookup the provider is not asked
+// again and merged pull requests are no longer checked.
+func (d *mergeDetector) branchPRs(branch string) []mergedPR {
+	if prs, ok := d.prs[branch]; ok || d.provider == nil {
+		return prs
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	found, err := branchPRs(ctx, d.provider, branch, "closed")
+	if err != nil {
+		d.provider = nil
+		d.warnings = append(d.warnings, fmt.Sprintf("pull request lookup failed, merged pull requests were not checked: %v", err))
+		return nil
+	}
+	var prs []mergedPR
+	for _, pr := range found {
+		if pr.Merged {
+			prs = append(prs, mergedPR{number: pr.Number, head: pr.HeadSHA})
+		}
+	}
+	d.prs[branch] = prs
+	return prs
+}
@@ -1,6 +1,9 @@
 package workflow
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -229,3 +232,285 @@ func TestCleanupSelectedDeletesOnlySelected(t *testing.T) {
 		t.Fatalf("feature/b should remain")
 	}
 }
+
+func cleanupReasons(t *testing.T, cfg *config.Config, repo string) map[string]string {
+	t.Helper()
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+	reasons := make(map[string]string)
+	for _, c := range out.Candidates {
+		reasons[c.Name] = c.Reason
+	}
+	return reasons
+}
+
+func TestCleanupDetectsSquashAndRebaseMerges(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/squashed")
+	commitFile(t, repo, "s1.txt", "one", "squash one")
+	commitFile(t, repo, "s2.txt", "two", "squash two")
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/rebased", "main")
+	commitFile(t, repo, "r1.txt", "one", "rebase one")
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/open", "main")
+	commitFile(t, repo, "o1.txt", "one", "still open")
+
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	commitFile(t, repo, "main.txt", "main", "main moves on")
+	runGitCleanup(t, repo, nil, "merge", "--squash", "feature/squashed")
+	runGitCleanup(t, repo, nil, "commit", "-m", "squashed feature")
+	runGitCleanup(t, repo, nil, "cherry-pick", "feature/rebased")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Cleanup.MergedOnly = true
+	cfg.Workflows.Cleanup.ProtectedBranches = []string{"main"}
+
+	reasons := cleanupReasons(t, cfg, repo)
+	if reasons["feature/squashed"] != "squash-merged" {
+		t.Fatalf("expected squash-merged, got %q", reasons["feature/squashed"])
+	}
+	if reasons["feature/rebased"] != "rebase-merged" {
+		t.Fatalf("expected rebase-merged, got %q", reasons["feature/rebased"])
+	}
+	if _, ok := reasons["feature/open"]; ok {
+		t.Fatalf("expected unmerged branch to be left alone")
+	}
+
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Remote: "origin", Yes: true})
+	if err != nil {
+		t.Fatalf("Cleanup delete: %v", err)
+	}
+	if len(out.Deleted) != 2 {
+		t.Fatalf("expected 2 deletions, got %v", out.Deleted)
+	}
+}
+
+func TestCleanupUsesMergedPullRequests(t *testing.T) {
+	var mergedHead, reusedHead string
+	var heads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		head := r.URL.Query().Get("head")
+		heads = append(heads, head)
+		switch head {
+		case "acme:feature/remote-merged":
+			fmt.Fprintf(w, `[{"number": 7, "state": "closed", "merged_at": "2024-01-01T00:00:00Z", "head": {"ref": "feature/remote-merged", "sha": %q}, "base": {"ref": "main"}}]`, mergedHead)
+		case "acme:feature/reused":
+			fmt.Fprintf(w, `[{"number": 8, "state": "closed", "merged_at": "2024-01-01T00:00:00Z", "head": {"ref": "feature/reused", "sha": %q}, "base": {"ref": "main"}}]`, reusedHead)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GITFLOW_TEST_TOKEN", "token")
+
+	repo := setupRepoForCleanup(t)
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/remote-merged")
+	commitFile(t, repo, "pr.txt", "pr", "pr change")
+	mergedHead = gitOutput(t, repo, "rev-parse", "HEAD")
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/reused", "main")
+	commitFile(t, repo, "reused.txt", "first", "first change")
+	reusedHead = gitOutput(t, repo, "rev-parse", "HEAD")
+	commitFile(t, repo, "reused.txt", "second", "new work after the merge")
+
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	commitFile(t, repo, "pr.txt", "edited during review", "landed differently")
+	commitFile(t, repo, "reused.txt", "first, reviewed", "first change landed")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Cleanup.ProtectedBranches = []string{"main"}
+	cfg.Provider = config.ProviderConfig{
+		Type:     "github",
+		BaseURL:  server.URL,
+		TokenEnv: "GITFLOW_TEST_TOKEN",
+		Owner:    "acme",
+		Repo:     "repo",
+	}
+
+	reasons := cleanupReasons(t, cfg, repo)
+	if reasons["feature/remote-merged"] != "merged (PR #7)" {
+		t.Fatalf("expected merged PR reason, got %q", reasons["feature/remote-merged"])
+	}
+	if reason, ok := reasons["feature/reused"]; ok {
+		t.Fatalf("expected branch with commits past its merged PR to be kept, got %q", reason)
+	}
+	for _, head := range heads {
+		if head == "" {
+			t.Fatalf("expected pull requests to be looked up per branch, got %v", heads)
+		}
+	}
+}
+
+func TestCleanupWarnsWhenPullRequestLookupFails(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message": "boom"}`, http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	t.Setenv("GITFLOW_TEST_TOKEN", "token")
+
+	repo := setupRepoForCleanup(t)
+	for _, name := range []string{"feature/one", "feature/two"} {
+		runGitCleanup(t, repo, nil, "checkout", "-b", name, "main")
+		commitFile(t, repo, name[len("feature/"):]+".txt", name, "work on "+name)
+	}
+	runGitCleanup(t, repo, nil, "checkout", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Provider = config.ProviderConfig{
+		Type:     "github",
+		BaseURL:  server.URL,
+		TokenEnv: "GITFLOW_TEST_TOKEN",
+		Owner:    "acme",
+		Repo:     "repo",
+	}
+
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+	if len(out.Warnings) != 1 || !strings.Contains(out.Warnings[0], "pull request lookup failed") {
+		t.Fatalf("expected one lookup warning, got %v", out.Warnings)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the provider to be asked once after a failure, got %d calls", calls)
+	}
+}
+
+func TestCleanupGoneFindsDeletedUpstreams(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/gone")
+	commitFile(t, repo, "g.txt", "g", "gone work")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/gone")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/kept", "main")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/kept")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	runGitCleanup(t, repo, nil, "push", "origin", "--delete", "feature/gone")
+	runGitCleanup(t, repo, nil, "update-ref", "refs/remotes/origin/feature/gone", "feature/gone")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Cleanup.ProtectedBranches = []string{"main"}
+
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Mode: CleanupGone})
+	if err != nil {
+		t.Fatalf("Cleanup gone: %v", err)
+	}
+	if len(out.Candidates) != 1 || out.Candidates[0].Name != "feature/gone" {
+		t.Fatalf("expected only feature/gone, got %+v", out.Candidates)
+	}
+	if out.Candidates[0].Reason != "upstream gone" {
+		t.Fatalf("unexpected reason %q", out.Candidates[0].Reason)
+	}
+}
+
+func TestCleanupRemoteBranchesBatchesDeletion(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/done")
+	commitFile(t, repo, "d.txt", "d", "done work")
+	runGitCleanup(t, repo, nil, "push", "origin", "feature/done")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "release/1.0", "feature/done")
+	runGitCleanup(t, repo, nil, "push", "origin", "release/1.0")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/wip", "main")
+	commitFile(t, repo, "w.txt", "w", "wip work")
+	runGitCleanup(t, repo, nil, "push", "origin", "feature/wip")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	runGitCleanup(t, repo, nil, "merge", "--no-ff", "feature/done", "-m", "merge done")
+	runGitCleanup(t, repo, nil, "push", "origin", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Cleanup.AgeThresholdDays = 30
+	cfg.Workflows.Cleanup.ProtectedBranches = []string{"main", "release/1.0"}
+
+	preview, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Mode: CleanupRemote})
+	if err != nil {
+		t.Fatalf("Cleanup remote: %v", err)
+	}
+	if len(preview.Candidates) != 1 || preview.Candidates[0].Name != "feature/done" {
+		t.Fatalf("expected only feature/done, got %+v", preview.Candidates)
+	}
+	if preview.Candidates[0].Author != "Test User" {
+		t.Fatalf("expected author, got %q", preview.Candidates[0].Author)
+	}
+	if len(preview.RemoteDeleted) != 0 {
+		t.Fatalf("expected no deletion without confirmation")
+	}
+
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, Mode: CleanupRemote, Yes: true})
+	if err != nil {
+		t.Fatalf("Cleanup remote delete: %v", err)
+	}
+	if len(out.RemoteDeleted) != 1 {
+		t.Fatalf("expected one remote deletion, got %v", out.RemoteDeleted)
+	}
+
+	client, _ := git.NewClient(repo)
+	heads, _ := client.Run("ls-remote", "--heads", "origin")
+	if strings.Contains(heads, "feature/done") || !strings.Contains(heads, "release/1.0") {
+		t.Fatalf("unexpected remote heads after cleanup: %s", heads)
+	}
+}
+
+func TestCleanupSkipsProtectedPatterns(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	for _, b := range []string{"release/1.0", "support/2.x/fix", "env/staging", "feature/done"} {
+		runGitCleanup(t, repo, nil, "branch", b, "main")
+	}
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Cleanup.ProtectedBranches = []string{"main", "release/*"}
+	cfg.Branches.Protected = []string{"support/**", "env/(prod|staging)"}
+
+	reasons := cleanupReasons(t, cfg, repo)
+	if len(reasons) != 1 || reasons["feature/done"] == "" {
+		t.Fatalf("expected only feature/done as candidate, got %v", reasons)
+	}
+}
+
+func TestCleanupCandidatesCarryPickerDetails(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/done")
+	commitFile(t, repo, "d.txt", "d", "finish done")
+	runGitCleanup(t, repo, nil, "push", "origin", "feature/done")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/wip", "main")
+	commitFile(t, repo, "w.txt", "w", "start wip")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	runGitCleanup(t, repo, nil, "merge", "--no-ff", "feature/done", "-m", "merge done")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Cleanup.MergedOnly = false
+	cfg.Workflows.Cleanup.AgeThresholdDays = 0
+
+	out, err := Cleanup(cfg, CleanupOptions{RepoPath: repo, DeleteRemote: true})
+	if err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+
+	got := make(map[string]CandidateBranch)
+	for _, c := range out.Candidates {
+		got[c.Name] = c
+	}
+	done, wip := got["feature/done"], got["feature/wip"]
+	if done.Unmerged || !done.OnRemote || done.LastCommit != "finish done" {
+		t.Fatalf("unexpected feature/done candidate %+v", done)
+	}
+	if !wip.Unmerged || wip.OnRemote || wip.LastCommit != "start wip" {
+		t.Fatalf("unexpected feature/wip candidate %+v", wip)
+	}
+}
@@ -27,6 +27,9 @@ type CommitOptions struct {
 // CommitResult reports the created commit message.
 type CommitResult struct {
 	Message string
+	Branch  string
+	// Protected is the branches.protected pattern matching Branch, if any.
+	Protected string
 }
 
 // Commit creates a commit using workflow settings.
@@ -59,11 +62,22 @@ func Commit(cfg *config.Config, opts CommitOptions) (*CommitResult, error) {
 		return nil, err
 	}
 
+	matcher, err := cfg.ProtectedMatcher()
+	if err != nil {
+		return nil, ConfigError{Err: err}
+	}
+
 	if err := client.CommitMessage(msg); err != nil {
 		return nil, err
 	}
 
-	return &CommitResult{Message: msg}, nil
+	res := &CommitResult{Message: msg}
+	// Detached HEAD has no branch to protect.
+	if branch, err := client.CurrentBranch(); err == nil && branch != "HEAD" {
+		res.Branch = branch
+		res.Protected, _ = matcher.Match(branch)
+	}
+	return res, nil
 }
 
 func buildCommitMessage(cfg *config.Config, opts CommitOptions) (string, error) {
@@ -78,3 +78,24 @@ func TestCommitRequiresStagedChanges(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestCommitReportsProtectedBranch(t *testing.T) {
+	repo := setupCommitRepo(t)
+	runGitCommitTest(t, repo, "checkout", "-b", "release/1.0")
+
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("b"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitCommitTest(t, repo, "add", "-A")
+
+	cfg := config.Default()
+	cfg.Branches.Protected = []string{"release/*"}
+
+	out, err := Commit(cfg, CommitOptions{RepoPath: repo, Message: "hotfix on release"})
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if out.Branch != "release/1.0" || out.Protected != "release/*" {
+		t.Fatalf("expected protected release branch, got %+v", out)
+	}
+}
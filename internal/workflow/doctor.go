@@ -11,9 +11,9 @@ import (
 
 const (
 	// DoctorOK indicates a successful check.
-	DoctorOK    = "OK"
+	DoctorOK = "OK"
 	// DoctorWarn indicates a warning check.
-	DoctorWarn  = "WARN"
+	DoctorWarn = "WARN"
 	// DoctorError indicates a failed check.
 	DoctorError = "ERROR"
 )
@@ -23,6 +23,23 @@ type DoctorCheck struct {
 	Name    string
 	Level   string
 	Message string
+	// Fix suggests how to resolve a WARN or ERROR check.
+	Fix string
+	// Action is set when doctor --fix can resolve the check itself.
+	Action *DoctorAction
+}
+
+// DoctorAction is a safe remediation doctor --fix can apply.
+type DoctorAction struct {
+	Description string
+	Apply       func() error
+}
+
+// DoctorChange records a check whose level changed between two runs.
+type DoctorChange struct {
+	Name   string
+	Before string
+	After  string
 }
 
 // DoctorResult aggregates doctor checks.
@@ -52,6 +69,7 @@ func Doctor(repoPath string) (*DoctorResult, error) {
 		Level:   DoctorOK,
 		Message: "Repository detected",
 	})
+	result.Checks = append(result.Checks, gitVersionCheck(client))
 
 	dirty, err := client.IsDirty()
 	if err != nil {
@@ -65,6 +83,7 @@ func Doctor(repoPath string) (*DoctorResult, error) {
 			Name:    "Working tree",
 			Level:   DoctorWarn,
 			Message: "Working tree has uncommitted changes",
+			Fix:     "Commit or stash the changes, or pass --autostash to start and sync",
 		})
 	} else {
 		result.Checks = append(result.Checks, DoctorCheck{
@@ -91,6 +110,18 @@ func Doctor(repoPath string) (*DoctorResult, error) {
 			Name:    "Config presence",
 			Level:   DoctorWarn,
 			Message: "Config file not found; run gitflow init",
+			Fix:     "Run gitflow init to write a starter .gitflow.yml",
+			Action: &DoctorAction{
+				Description: fmt.Sprintf("Write the config gitflow init --yes would to %s", filepath.Join(root, ".gitflow.yml")),
+				Apply: func() error {
+					defaults, err := DetectInit(root)
+					if err != nil {
+						return err
+					}
+					_, err = Init(defaults.Config(), InitOptions{RepoPath: root})
+					return err
+				},
+			},
 		})
 	}
 
@@ -102,7 +133,7 @@ func Doctor(repoPath string) (*DoctorResult, error) {
 			Message: loadErr.Error(),
 		})
 	} else if configExists {
-		if err := config.ValidateStrict(res.Config); err != nil {
+		if err := config.ValidateResult(res); err != nil {
 			result.Checks = append(result.Checks, DoctorCheck{
 				Name:    "Config validity",
 				Level:   DoctorError,
@@ -123,50 +154,72 @@ func Doctor(repoPath string) (*DoctorResult, error) {
 		})
 	}
 
-	if loadErr != nil {
-		result.Checks = append(result.Checks, DoctorCheck{
-			Name:    "Provider token",
-			Level:   DoctorWarn,
-			Message: "Skipping provider token check due to invalid config",
-		})
-		return result, nil
+	cfg := config.Default()
+	if loadErr == nil {
+		cfg = res.Config
 	}
+	result.Checks = append(result.Checks, providerTokenCheck(cfg, loadErr))
+	result.Checks = append(result.Checks,
+		originCheck(client, cfg),
+		baseBranchCheck(client, cfg),
+		upstreamCheck(client),
+		goneBranchesCheck(client, cfg, root),
+		identityCheck(client),
+		signingCheck(client),
+		hooksCheck(client, cfg),
+	)
 
-	provider := res.Config.Provider
-	if provider.Type == "" {
-		result.Checks = append(result.Checks, DoctorCheck{
-			Name:    "Provider token",
-			Level:   DoctorOK,
-			Message: "Provider not configured",
-		})
-		return result, nil
-	}
+	return result, nil
+}
 
-	if provider.TokenEnv == "" {
-		result.Checks = append(result.Checks, DoctorCheck{
-			Name:    "Provider token",
-			Level:   DoctorWarn,
-			Message: "provider.token_env is not set",
-		})
-		return result, nil
+// DiffDoctor lists the checks whose level differs between two doctor runs,
+// including checks that only appear in one of them.
+func DiffDoctor(before, after *DoctorResult) []DoctorChange {
+	levels := make(map[string]string, len(before.Checks))
+	for _, check := range before.Checks {
+		levels[check.Name] = check.Level
 	}
 
-	if os.Getenv(provider.TokenEnv) == "" {
-		result.Checks = append(result.Checks, DoctorCheck{
-			Name:    "Provider token",
-			Level:   DoctorWarn,
-			Message: fmt.Sprintf("Missing %s environment variable", provider.TokenEnv),
-		})
-		return result, nil
+	var changes []DoctorChange
+	seen := make(map[string]bool, len(after.Checks))
+	for _, check := range after.Checks {
+		seen[check.Name] = true
+		if levels[check.Name] != check.Level {
+			changes = append(changes, DoctorChange{Name: check.Name, Before: levels[check.Name], After: check.Level})
+		}
 	}
+	for _, check := range before.Checks {
+		if !seen[check.Name] {
+			changes = append(changes, DoctorChange{Name: check.Name, Before: check.Level})
+		}
+	}
+	return changes
+}
 
-	result.Checks = append(result.Checks, DoctorCheck{
-		Name:    "Provider token",
-		Level:   DoctorOK,
-		Message: fmt.Sprintf("%s is set", provider.TokenEnv),
-	})
-
-	return result, nil
+func providerTokenCheck(cfg *config.Config, loadErr error) DoctorCheck {
+	check := DoctorCheck{Name: "Provider token"}
+	provider := cfg.Provider
+	switch {
+	case loadErr != nil:
+		check.Level = DoctorWarn
+		check.Message = "Skipping provider token check due to invalid config"
+		check.Fix = "Fix the config errors reported above"
+	case provider.Type == "":
+		check.Level = DoctorOK
+		check.Message = "Provider not configured"
+	case provider.TokenEnv == "":
+		check.Level = DoctorWarn
+		check.Message = "provider.token_env is not set"
+		check.Fix = "Set provider.token_env to the environment variable holding your token"
+	case os.Getenv(provider.TokenEnv) == "":
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("Missing %s environment variable", provider.TokenEnv)
+		check.Fix = fmt.Sprintf("export %s=<token>", provider.TokenEnv)
+	default:
+		check.Level = DoctorOK
+		check.Message = fmt.Sprintf("%s is set", provider.TokenEnv)
+	}
+	return check
 }
 
 func repoConfigPath(root string) (string, bool) {
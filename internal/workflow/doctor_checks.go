@@ -0,0 +1,525 @@
+package workflow
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+// MinGitVersion is the oldest git gitflow supports: git stash push and
+// rev-parse --absolute-git-dir arrived in 2.13.
+const MinGitVersion = "2.13.0"
+
+// fastBranchListVersion adds the for-each-ref ahead-behind atom.
+const fastBranchListVersion = "2.41.0"
+
+func gitVersionCheck(client *git.Client) DoctorCheck {
+	check := DoctorCheck{Name: "Git version"}
+	version, err := client.Version()
+	if err != nil {
+		check.Level = DoctorError
+		check.Message = err.Error()
+		return check
+	}
+
+	switch {
+	case compareGitVersions(version, MinGitVersion) < 0:
+		check.Level = DoctorError
+		check.Message = fmt.Sprintf("git %s is older than the minimum %s", version, MinGitVersion)
+		check.Fix = fmt.Sprintf("Upgrade git to %s or newer", MinGitVersion)
+	case compareGitVersions(version, fastBranchListVersion) < 0:
+		check.Level = DoctorOK
+		check.Message = fmt.Sprintf("git %s (%s+ lists branches faster)", version, fastBranchListVersion)
+	default:
+		check.Level = DoctorOK
+		check.Message = "git " + version
+	}
+	return check
+}
+
+// compareGitVersions compares dotted versions numerically, ignoring vendor
+// suffixes such as 2.39.3 (Apple Git-145) or 2.42.0.windows.1.
+func compareGitVersions(a, b string) int {
+	pa, pb := gitVersionParts(a), gitVersionParts(b)
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func gitVersionParts(v string) [3]int {
+	var parts [3]int
+	for i, f := range strings.SplitN(v, ".", 4) {
+		if i == len(parts) {
+			break
+		}
+		end := 0
+		for end < len(f) && f[end] >= '0' && f[end] <= '9' {
+			end++
+		}
+		parts[i], _ = strconv.Atoi(f[:end])
+	}
+	return parts
+}
+
+func originCheck(client *git.Client, cfg *config.Config) DoctorCheck {
+	check := DoctorCheck{Name: "Remote origin"}
+	provider := cfg.Provider
+	expected := providerRemoteURL(cfg)
+
+	hasOrigin, err := client.HasRemote("origin")
+	if err != nil {
+		check.Level = DoctorError
+		check.Message = err.Error()
+		return check
+	}
+	if !hasOrigin {
+		check.Level = DoctorWarn
+		check.Message = "No origin remote; push, sync and pull requests need one"
+		check.Fix = "git remote add origin <url>"
+		if expected != "" {
+			check.Fix = "git remote add origin " + expected
+			check.Action = &DoctorAction{
+				Description: "Add origin " + expected + " from the provider config",
+				Apply:       func() error { return client.AddRemote("origin", expected) },
+			}
+		}
+		return check
+	}
+
+	remoteURL, err := client.RemoteURL("origin")
+	if err != nil {
+		check.Level = DoctorError
+		check.Message = err.Error()
+		return check
+	}
+	if provider.Type == "" {
+		check.Level = DoctorOK
+		check.Message = remoteURL
+		return check
+	}
+
+	info, ok := git.ParseRemoteURL(remoteURL)
+	if !ok {
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("Cannot read owner/repo from %s", remoteURL)
+		check.Fix = "git remote set-url origin " + expected
+		return check
+	}
+	if !strings.EqualFold(info.Owner, provider.Owner) || !strings.EqualFold(info.Repo, provider.Repo) {
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("origin points at %s/%s but provider is %s/%s", info.Owner, info.Repo, provider.Owner, provider.Repo)
+		check.Fix = fmt.Sprintf("Set provider.owner: %s and provider.repo: %s, or git remote set-url origin %s", info.Owner, info.Repo, expected)
+		return check
+	}
+
+	check.Level = DoctorOK
+	check.Message = fmt.Sprintf("%s matches %s/%s", remoteURL, provider.Owner, provider.Repo)
+	return check
+}
+
+// providerRemoteURL builds the https clone URL for the configured provider
+// repository, or returns "" when no provider is configured.
+func providerRemoteURL(cfg *config.Config) string {
+	p := cfg.Provider
+	if p.Type == "" || p.Owner == "" || p.Repo == "" {
+		return ""
+	}
+
+	host := ""
+	switch p.Type {
+	case "github":
+		host = "github.com"
+	case "gitlab":
+		host = "gitlab.com"
+	}
+	if p.BaseURL != "" {
+		if u, err := url.Parse(p.BaseURL); err == nil && u.Hostname() != "" {
+			host = strings.TrimPrefix(u.Host, "api.")
+		}
+	}
+	if host == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s/%s/%s.git", host, p.Owner, p.Repo)
+}
+
+func baseBranchCheck(client *git.Client, cfg *config.Config) DoctorCheck {
+	check := DoctorCheck{Name: "Base branch"}
+	base := startBaseBranch(cfg)
+
+	if _, err := client.HeadCommit(); err != nil {
+		check.Level = DoctorWarn
+		check.Message = "Repository has no commits yet"
+		check.Fix = fmt.Sprintf("Create an initial commit on %s", base)
+		return check
+	}
+
+	local, _ := client.BranchExists("refs/heads/" + base)
+	hasOrigin, _ := client.HasRemote("origin")
+	remote := false
+	if hasOrigin {
+		remote, _ = client.BranchExists("refs/remotes/origin/" + base)
+	}
+
+	switch {
+	case local && (remote || !hasOrigin):
+		check.Level = DoctorOK
+		check.Message = fmt.Sprintf("%s exists", base)
+		if remote {
+			check.Message += " locally and on origin"
+		}
+	case local:
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("%s is not on origin", base)
+		check.Fix = fmt.Sprintf("git push -u origin %s", base)
+	case remote:
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("%s only exists on origin", base)
+		check.Fix = fmt.Sprintf("git branch --track %s origin/%s", base, base)
+		check.Action = &DoctorAction{
+			Description: fmt.Sprintf("Create %s tracking origin/%s", base, base),
+			Apply: func() error {
+				if err := client.CreateBranchAt(base, "refs/remotes/origin/"+base); err != nil {
+					return err
+				}
+				return client.SetUpstream(base, "origin", base)
+			},
+		}
+	default:
+		check.Level = DoctorError
+		check.Message = fmt.Sprintf("Base branch %s not found", base)
+		check.Fix = "Create it, or set workflows.start.base_branch or branches.main_branch in .gitflow.yml"
+	}
+	return check
+}
+
+func upstreamCheck(client *git.Client) DoctorCheck {
+	check := DoctorCheck{Name: "Upstream"}
+	s, err := client.TreeStatus()
+	if err != nil {
+		check.Level = DoctorError
+		check.Message = err.Error()
+		return check
+	}
+
+	switch {
+	case s.Head == "":
+		check.Level = DoctorOK
+		check.Message = "No commits yet"
+	case s.Detached:
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("HEAD is detached at %.7s", s.Head)
+		check.Fix = "git switch <branch>"
+	case s.Upstream == "":
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("%s has no upstream", s.Branch)
+		check.Fix = fmt.Sprintf("git push -u origin %s", s.Branch)
+		check.Action = upstreamAction(client, s.Branch)
+	case s.UpstreamGone:
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("Upstream %s of %s was deleted", s.Upstream, s.Branch)
+		check.Fix = "git branch --unset-upstream, or gitflow cleanup --gone once it is merged"
+	case s.Ahead > 0 && s.Behind > 0:
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("%s and %s have diverged (ahead %d, behind %d)", s.Branch, s.Upstream, s.Ahead, s.Behind)
+		check.Fix = "gitflow sync"
+	default:
+		check.Level = DoctorOK
+		check.Message = fmt.Sprintf("%s tracks %s (ahead %d, behind %d)", s.Branch, s.Upstream, s.Ahead, s.Behind)
+	}
+	return check
+}
+
+// upstreamAction tracks origin/<branch> when it already exists. Pushing is
+// left to the user, since doctor --fix never publishes commits.
+func upstreamAction(client *git.Client, branch string) *DoctorAction {
+	if ok, _ := client.BranchExists("refs/remotes/origin/" + branch); !ok {
+		return nil
+	}
+	return &DoctorAction{
+		Description: fmt.Sprintf("Set the upstream of %s to origin/%s", branch, branch),
+		Apply:       func() error { return client.SetUpstream(branch, "origin", branch) },
+	}
+}
+
+func goneBranchesCheck(client *git.Client, cfg *config.Config, root string) DoctorCheck {
+	check := DoctorCheck{Name: "Gone branches"}
+	gone, err := client.GoneBranches()
+	if err != nil {
+		check.Level = DoctorError
+		check.Message = err.Error()
+		return check
+	}
+	if len(gone) == 0 {
+		check.Level = DoctorOK
+		check.Message = "No branches track a deleted upstream"
+		return check
+	}
+
+	check.Level = DoctorWarn
+	check.Message = fmt.Sprintf("Upstream deleted for %s", strings.Join(gone, ", "))
+	check.Fix = "gitflow cleanup --gone"
+	check.Action = &DoctorAction{
+		Description: "Delete gone branches that are merged into the base branch",
+		Apply:       func() error { return pruneGoneBranches(cfg, root) },
+	}
+	return check
+}
+
+// pruneGoneBranches runs cleanup --gone on the merged candidates only, so
+// unmerged work is never force deleted by doctor.
+func pruneGoneBranches(cfg *config.Config, root string) error {
+	opts := CleanupOptions{RepoPath: root, Mode: CleanupGone}
+	res, err := Cleanup(cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range res.Candidates {
+		if !c.Unmerged {
+			opts.Selected = append(opts.Selected, c.Name)
+		}
+	}
+	if len(opts.Selected) == 0 {
+		return fmt.Errorf("no merged gone branches to delete; review the rest with gitflow cleanup --gone")
+	}
+	opts.Yes = true
+	_, err = Cleanup(cfg, opts)
+	return err
+}
+
+func identityCheck(client *git.Client) DoctorCheck {
+	check := DoctorCheck{Name: "Git identity"}
+	name, _ := client.ConfigValue("user.name")
+	email, _ := client.ConfigValue("user.email")
+
+	var missing []string
+	var fixes []string
+	if name == "" {
+		missing = append(missing, "user.name")
+		fixes = append(fixes, `git config --global user.name "Your Name"`)
+	}
+	if email == "" {
+		missing = append(missing, "user.email")
+		fixes = append(fixes, "git config --global user.email you@example.com")
+	}
+	if len(missing) > 0 {
+		check.Level = DoctorError
+		check.Message = strings.Join(missing, " and ") + " not set; commits will fail"
+		check.Fix = strings.Join(fixes, " && ")
+		return check
+	}
+
+	check.Level = DoctorOK
+	check.Message = fmt.Sprintf("%s <%s>", name, email)
+	return check
+}
+
+func signingCheck(client *git.Client) DoctorCheck {
+	check := DoctorCheck{Name: "Commit signing"}
+	if !configBool(client, "commit.gpgsign") && !configBool(client, "tag.gpgsign") {
+		check.Level = DoctorOK
+		check.Message = "Signing disabled"
+		return check
+	}
+
+	format, _ := client.ConfigValue("gpg.format")
+	if format == "" {
+		format = "openpgp"
+	}
+	key, _ := client.ConfigValue("user.signingkey")
+
+	programKey, program := "gpg.program", "gpg"
+	switch format {
+	case "ssh":
+		programKey, program = "gpg.ssh.program", "ssh-keygen"
+	case "x509":
+		programKey, program = "gpg.x509.program", "gpgsm"
+	}
+	if v, _ := client.ConfigValue(programKey); v != "" {
+		program = v
+	}
+	if _, err := exec.LookPath(program); err != nil {
+		check.Level = DoctorError
+		check.Message = fmt.Sprintf("Signing is enabled but %s was not found", program)
+		check.Fix = fmt.Sprintf("Install %s or set %s", program, programKey)
+		return check
+	}
+
+	switch {
+	case format == "ssh" && key == "":
+		check.Level = DoctorError
+		check.Message = "SSH signing is enabled but user.signingkey is not set"
+		check.Fix = "git config user.signingkey ~/.ssh/id_ed25519.pub"
+	case format == "ssh" && !strings.HasPrefix(key, "key::") && !fileExists(expandHome(key)):
+		check.Level = DoctorError
+		check.Message = fmt.Sprintf("SSH signing key %s does not exist", key)
+		check.Fix = "git config user.signingkey <path to public key>"
+	case key == "":
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("%s signing uses the committer email because user.signingkey is not set", format)
+		check.Fix = "git config user.signingkey <key id>"
+	default:
+		check.Level = DoctorOK
+		check.Message = fmt.Sprintf("Signing with %s key %s", format, key)
+	}
+	return check
+}
+
+func configBool(client *git.Client, key string) bool {
+	v, err := client.Run("config", "--bool", "--get", key)
+	return err == nil && v == "true"
+}
+
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
+func hooksCheck(client *git.Client, cfg *config.Config) DoctorCheck {
+	check := DoctorCheck{Name: "Hooks"}
+	dir, err := client.HooksDir()
+	if err != nil {
+		check.Level = DoctorError
+		check.Message = err.Error()
+		return check
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		check.Level = DoctorError
+		check.Message = err.Error()
+		return check
+	}
+	if os.IsNotExist(err) {
+		if hooksPath, _ := client.ConfigValue("core.hooksPath"); hooksPath != "" {
+			check.Level = DoctorWarn
+			check.Message = fmt.Sprintf("core.hooksPath points at missing directory %s", hooksPath)
+			check.Fix = "Create the directory or git config --unset core.hooksPath"
+			return check
+		}
+	}
+
+	var installed, disabled []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), ".sample") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if info.Mode().Perm()&0o111 == 0 {
+			disabled = append(disabled, e.Name())
+			continue
+		}
+		installed = append(installed, e.Name())
+	}
+	sort.Strings(installed)
+	sort.Strings(disabled)
+
+	hasCommitMsg := false
+	for _, h := range installed {
+		hasCommitMsg = hasCommitMsg || h == "commit-msg"
+	}
+
+	switch {
+	case len(disabled) > 0:
+		check.Level = DoctorWarn
+		check.Message = fmt.Sprintf("Not executable, so git skips them: %s", strings.Join(disabled, ", "))
+		check.Fix = fmt.Sprintf("chmod +x %s", filepath.Join(dir, disabled[0]))
+		if len(disabled) > 1 {
+			check.Fix = fmt.Sprintf("chmod +x in %s", dir)
+		}
+		check.Action = &DoctorAction{
+			Description: fmt.Sprintf("Make %s executable", strings.Join(disabled, ", ")),
+			Apply: func() error {
+				for _, name := range disabled {
+					if err := os.Chmod(filepath.Join(dir, name), 0o755); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	case cfg.Commits.Conventional && !hasCommitMsg:
+		check.Level = DoctorWarn
+		check.Message = "commits.conventional is on but no commit-msg hook checks messages made outside gitflow"
+		check.Fix = "Add a commit-msg hook that enforces conventional commits"
+		check.Action = &DoctorAction{
+			Description: "Install a commit-msg hook in " + dir,
+			Apply:       func() error { return installCommitMsgHook(dir, cfg) },
+		}
+	case len(installed) == 0:
+		check.Level = DoctorOK
+		check.Message = "No hooks installed"
+	default:
+		check.Level = DoctorOK
+		check.Message = "Installed: " + strings.Join(installed, ", ")
+	}
+	return check
+}
+
+// commitMsgHook rejects commit messages whose first line is not a
+// conventional commit header. Merges, reverts and fixups are let through.
+const commitMsgHook = `#!/bin/sh
+# Installed by gitflow doctor --fix.
+header=$(head -n 1 "$1")
+case "$header" in
+	"Merge "*|"Revert "*|"fixup! "*|"squash! "*|"amend! "*) exit 0 ;;
+esac
+if ! printf '%%s\n' "$header" | grep -Eq '%s'; then
+	echo "commit-msg: expected a conventional commit header such as \"feat(scope): summary\"" >&2
+	echo "commit-msg: got \"$header\"" >&2
+	exit 1
+fi
+`
+
+func installCommitMsgHook(dir string, cfg *config.Config) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "commit-msg")
+	if fileExists(path) {
+		return fmt.Errorf("%s already exists", path)
+	}
+	return os.WriteFile(path, []byte(fmt.Sprintf(commitMsgHook, conventionalHeaderPattern(cfg))), 0o755)
+}
+
+// conventionalHeaderPattern builds the extended regex the commit-msg hook
+// matches headers against from commits.types and commits.scopes.
+func conventionalHeaderPattern(cfg *config.Config) string {
+	types := "[a-z]+"
+	if len(cfg.Commits.Types) > 0 {
+		types = "(" + strings.Join(cfg.Commits.Types, "|") + ")"
+	}
+	scope := `\([^)]+\)`
+	if len(cfg.Commits.Scopes) > 0 {
+		scope = `\((` + strings.Join(cfg.Commits.Scopes, "|") + `)\)`
+	}
+	optional := "?"
+	if cfg.Commits.RequireScope {
+		optional = ""
+	}
+	return "^" + types + "(" + scope + ")" + optional + "!?: .+"
+}
@@ -0,0 +1,167 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gitflow/internal/config"
+	"gitflow/internal/provider"
+)
+
+// rateLimitHeadroom is the fraction of the API allowance below which doctor
+// warns that gitflow may be throttled.
+const rateLimitHeadroom = 0.1
+
+// tokenExpiryWarning is how close to expiry a token is reported.
+const tokenExpiryWarning = 7 * 24 * time.Hour
+
+// DoctorOnline contacts the provider: it validates the token, then checks its
+// scopes, whether it can create pull requests and releases on the configured
+// repository, and how much of the API rate limit is left.
+func DoctorOnline(cfg *config.Config) (*DoctorResult, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	result := &DoctorResult{}
+	if !provider.Enabled(cfg) {
+		result.Checks = append(result.Checks, DoctorCheck{
+			Name:    "Provider auth",
+			Level:   DoctorOK,
+			Message: "Provider not configured; skipping online checks",
+		})
+		return result, nil
+	}
+
+	auth := DoctorCheck{Name: "Provider auth"}
+	pcfg, err := provider.FromAppConfig(cfg)
+	if err != nil {
+		auth.Level = DoctorError
+		auth.Message = err.Error()
+		auth.Fix = fmt.Sprintf("export %s=<token>", cfg.Provider.TokenEnv)
+		result.Checks = append(result.Checks, auth)
+		return result, nil
+	}
+	p, err := provider.New(pcfg)
+	if err != nil {
+		auth.Level = DoctorError
+		auth.Message = err.Error()
+		result.Checks = append(result.Checks, auth)
+		return result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	repo := fmt.Sprintf("%s/%s", cfg.Provider.Owner, cfg.Provider.Repo)
+	if err := p.ValidateAuth(ctx); err != nil {
+		auth.Level = DoctorError
+		auth.Message = err.Error()
+		auth.Fix = fmt.Sprintf("Check %s and that provider.owner and provider.repo name %s", cfg.Provider.TokenEnv, repo)
+		result.Checks = append(result.Checks, auth)
+		return result, nil
+	}
+	auth.Level = DoctorOK
+	auth.Message = fmt.Sprintf("Authenticated to %s", repo)
+	result.Checks = append(result.Checks, auth)
+
+	inspector, ok := p.(provider.AccessInspector)
+	if !ok {
+		result.Checks = append(result.Checks, DoctorCheck{
+			Name:    "Token access",
+			Level:   DoctorWarn,
+			Message: fmt.Sprintf("%s does not report token permissions", cfg.Provider.Type),
+		})
+		return result, nil
+	}
+	access, err := inspector.Access(ctx)
+	if err != nil {
+		result.Checks = append(result.Checks, DoctorCheck{
+			Name:    "Token access",
+			Level:   DoctorWarn,
+			Message: err.Error(),
+		})
+		return result, nil
+	}
+
+	result.Checks = append(result.Checks,
+		tokenScopesCheck(access, time.Now()),
+		tokenPermissionCheck("Create pull requests", access.CanCreatePR, access, cfg, repo),
+		tokenPermissionCheck("Create releases", access.CanCreateRelease, access, cfg, repo),
+		rateLimitCheck(access.RateLimit),
+	)
+	return result, nil
+}
+
+func tokenScopesCheck(access *provider.AccessReport, now time.Time) DoctorCheck {
+	check := DoctorCheck{Name: "Token scopes", Level: DoctorOK}
+	switch {
+	case access.Scopes == nil:
+		check.Message = "Scopes not reported; relying on repository permissions"
+	case len(access.Scopes) == 0:
+		check.Message = "Token has no scopes"
+	default:
+		check.Message = strings.Join(access.Scopes, ", ")
+	}
+
+	if !access.ExpiresAt.IsZero() {
+		left := access.ExpiresAt.Sub(now)
+		switch {
+		case left <= 0:
+			check.Level = DoctorError
+			check.Message += fmt.Sprintf("; expired %s", access.ExpiresAt.Format("2006-01-02"))
+			check.Fix = "Create a new token"
+		case left < tokenExpiryWarning:
+			check.Level = DoctorWarn
+			check.Message += fmt.Sprintf("; expires %s", access.ExpiresAt.Format("2006-01-02"))
+			check.Fix = "Renew the token before it expires"
+		}
+	}
+	return check
+}
+
+func tokenPermissionCheck(name string, allowed bool, access *provider.AccessReport, cfg *config.Config, repo string) DoctorCheck {
+	check := DoctorCheck{Name: name}
+	if allowed {
+		check.Level = DoctorOK
+		check.Message = fmt.Sprintf("Allowed on %s (%s)", repo, access.Role)
+		if name == "Create pull requests" && cfg.Provider.Type == "gitlab" {
+			check.Level = DoctorWarn
+			check.Message += ", but gitflow does not create GitLab merge requests yet"
+		}
+		return check
+	}
+
+	check.Level = DoctorError
+	check.Message = fmt.Sprintf("Token role on %s is %s", repo, access.Role)
+	if access.Scopes != nil {
+		check.Message += fmt.Sprintf(" with scopes [%s]", strings.Join(access.Scopes, ", "))
+	}
+	switch cfg.Provider.Type {
+	case "github":
+		check.Fix = "Use a token with the repo scope, or contents and pull requests write access, from an account that can push"
+	case "gitlab":
+		check.Fix = "Use a token with the api scope from an account with at least the Developer role"
+	}
+	return check
+}
+
+func rateLimitCheck(rl provider.RateLimit) DoctorCheck {
+	check := DoctorCheck{Name: "Rate limit", Level: DoctorOK}
+	if rl.Limit == 0 {
+		check.Message = "Not reported"
+		return check
+	}
+
+	check.Message = fmt.Sprintf("%d of %d requests left", rl.Remaining, rl.Limit)
+	if !rl.Reset.IsZero() {
+		check.Message += fmt.Sprintf(", resets at %s", rl.Reset.Local().Format("15:04"))
+	}
+	if float64(rl.Remaining) < float64(rl.Limit)*rateLimitHeadroom {
+		check.Level = DoctorWarn
+		check.Fix = "Wait for the reset before running release or pull request commands"
+	}
+	return check
+}
@@ -1,9 +1,15 @@
 package workflow
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"gitflow/internal/config"
 )
 
 func TestDoctorNonGitDirectory(t *testing.T) {
@@ -63,3 +69,183 @@ func checkLevel(t *testing.T, res *DoctorResult, name string) string {
 	t.Fatalf("missing check %s", name)
 	return ""
 }
+
+func TestDoctorRepoChecks(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	res, err := Doctor(repo)
+	if err != nil {
+		t.Fatalf("Doctor: %v", err)
+	}
+	for _, name := range []string{"Git version", "Remote origin", "Base branch", "Upstream", "Git identity", "Commit signing"} {
+		if level := checkLevel(t, res, name); level != DoctorOK {
+			t.Fatalf("expected %s to be OK, got %s", name, level)
+		}
+	}
+
+	runGitCleanup(t, repo, nil, "switch", "-c", "feature/local")
+	runGitCleanup(t, repo, nil, "push", "origin", "--delete", "main")
+	runGitCleanup(t, repo, nil, "fetch", "--prune")
+
+	res, err = Doctor(repo)
+	if err != nil {
+		t.Fatalf("Doctor: %v", err)
+	}
+	upstream := findCheck(t, res, "Upstream")
+	if upstream.Level != DoctorWarn || upstream.Fix != "git push -u origin feature/local" || upstream.Action != nil {
+		t.Fatalf("unexpected upstream check: %+v", upstream)
+	}
+	base := findCheck(t, res, "Base branch")
+	if base.Level != DoctorWarn || base.Fix != "git push -u origin main" {
+		t.Fatalf("unexpected base branch check: %+v", base)
+	}
+}
+
+func TestDoctorOriginMismatch(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	runGitCleanup(t, repo, nil, "remote", "set-url", "origin", "git@github.com:other/project.git")
+
+	config := "provider:\n  type: github\n  token_env: GITFLOW_TEST_TOKEN\n  owner: acme\n  repo: repo\n"
+	if err := os.WriteFile(filepath.Join(repo, ".gitflow.yml"), []byte(config), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	res, err := Doctor(repo)
+	if err != nil {
+		t.Fatalf("Doctor: %v", err)
+	}
+	origin := findCheck(t, res, "Remote origin")
+	if origin.Level != DoctorWarn {
+		t.Fatalf("expected origin mismatch to warn, got %+v", origin)
+	}
+	if !strings.Contains(origin.Fix, "https://github.com/acme/repo.git") {
+		t.Fatalf("expected fix to suggest the provider URL, got %q", origin.Fix)
+	}
+}
+
+func TestDoctorMissingIdentity(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	runGitCleanup(t, repo, nil, "config", "--unset", "user.email")
+
+	res, err := Doctor(repo)
+	if err != nil {
+		t.Fatalf("Doctor: %v", err)
+	}
+	identity := findCheck(t, res, "Git identity")
+	if identity.Level != DoctorError || !strings.Contains(identity.Fix, "user.email") {
+		t.Fatalf("unexpected identity check: %+v", identity)
+	}
+}
+
+func findCheck(t *testing.T, res *DoctorResult, name string) DoctorCheck {
+	t.Helper()
+	for _, check := range res.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("missing check %s", name)
+	return DoctorCheck{}
+}
+
+func TestDoctorFixActions(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	commitFile(t, repo, ".gitflow.yml", "commits:\n  conventional: true\n", "chore: add config")
+	runGitCleanup(t, repo, nil, "push", "origin", "main")
+
+	runGitCleanup(t, repo, nil, "switch", "-c", "feature/done")
+	commitFile(t, repo, "done.txt", "done", "feat: done")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/done")
+	runGitCleanup(t, repo, nil, "switch", "main")
+	runGitCleanup(t, repo, nil, "merge", "--ff-only", "feature/done")
+	runGitCleanup(t, repo, nil, "push", "origin", "main", ":feature/done")
+	runGitCleanup(t, repo, nil, "fetch", "--prune")
+	runGitCleanup(t, repo, nil, "switch", "-c", "feature/new")
+	runGitCleanup(t, repo, nil, "push", "origin", "feature/new")
+
+	before, err := Doctor(repo)
+	if err != nil {
+		t.Fatalf("Doctor: %v", err)
+	}
+	for _, name := range []string{"Upstream", "Gone branches", "Hooks"} {
+		check := findCheck(t, before, name)
+		if check.Level != DoctorWarn || check.Action == nil {
+			t.Fatalf("expected a fixable warning for %s, got %+v", name, check)
+		}
+		if err := check.Action.Apply(); err != nil {
+			t.Fatalf("fix %s: %v", name, err)
+		}
+	}
+
+	after, err := Doctor(repo)
+	if err != nil {
+		t.Fatalf("Doctor: %v", err)
+	}
+	changes := DiffDoctor(before, after)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changed checks, got %+v", changes)
+	}
+	for _, change := range changes {
+		if change.After != DoctorOK {
+			t.Fatalf("expected %s to be fixed, got %+v", change.Name, change)
+		}
+	}
+
+	if out := gitOutput(t, repo, "branch", "--list", "feature/done"); out != "" {
+		t.Fatalf("expected gone branch to be deleted, got %q", out)
+	}
+	cmd := exec.Command("git", "commit", "--allow-empty", "-m", "not conventional")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Fatalf("expected commit-msg hook to reject the message:\n%s", out)
+	}
+	runGitCleanup(t, repo, nil, "commit", "--allow-empty", "-m", "fix(doctor): conventional")
+}
+
+func TestDoctorOnlineReportsPermissionsAndRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "repo")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "12")
+		w.Write([]byte(`{"permissions":{"pull":true}}`))
+	}))
+	defer server.Close()
+
+	cfg := config.Default()
+	cfg.Provider = config.ProviderConfig{Type: "github", BaseURL: server.URL, TokenEnv: "GITFLOW_TEST_TOKEN", Owner: "acme", Repo: "repo"}
+	t.Setenv("GITFLOW_TEST_TOKEN", "token")
+
+	res, err := DoctorOnline(cfg)
+	if err != nil {
+		t.Fatalf("DoctorOnline: %v", err)
+	}
+	want := map[string]string{
+		"Provider auth":        DoctorOK,
+		"Token scopes":         DoctorOK,
+		"Create pull requests": DoctorError,
+		"Create releases":      DoctorError,
+		"Rate limit":           DoctorWarn,
+	}
+	for name, level := range want {
+		if got := checkLevel(t, res, name); got != level {
+			t.Fatalf("expected %s to be %s, got %s", name, level, got)
+		}
+	}
+}
+
+func TestDoctorOnlineMissingToken(t *testing.T) {
+	cfg := config.Default()
+	cfg.Provider = config.ProviderConfig{Type: "github", TokenEnv: "GITFLOW_TEST_TOKEN", Owner: "acme", Repo: "repo"}
+	t.Setenv("GITFLOW_TEST_TOKEN", "")
+
+	res, err := DoctorOnline(cfg)
+	if err != nil {
+		t.Fatalf("DoctorOnline: %v", err)
+	}
+	auth := findCheck(t, res, "Provider auth")
+	if auth.Level != DoctorError || auth.Fix != "export GITFLOW_TEST_TOKEN=<token>" {
+		t.Fatalf("unexpected auth check: %+v", auth)
+	}
+}
@@ -1,5 +1,10 @@
 package workflow
 
+import (
+	"fmt"
+	"strings"
+)
+
 // ConfigError wraps configuration errors for workflow use.
 type ConfigError struct {
 	Err error
@@ -35,3 +40,46 @@ func (e ProviderError) Error() string {
 func (e ProviderError) Unwrap() error {
 	return e.Err
 }
+
+// SyncConflictError reports a sync that stopped on merge conflicts.
+type SyncConflictError struct {
+	Branch   string
+	Base     string
+	Strategy string
+	Files    []string
+	// Stash is the commit of changes autostashed before the sync, if any.
+	Stash string
+}
+
+// Error describes the conflicted files and how to proceed.
+func (e SyncConflictError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s of %s onto %s stopped on conflicts in %d file(s):", e.Strategy, e.Branch, e.Base, len(e.Files))
+	for _, f := range e.Files {
+		fmt.Fprintf(&b, "\n  %s", f)
+	}
+	b.WriteString("\nResolve the conflicts and stage them with git add, then run gitflow sync --continue")
+	b.WriteString("\nRun gitflow sync --abort to restore the branch to where it was")
+	if e.Stash != "" {
+		fmt.Fprintf(&b, "\nYour local changes are autostashed as %.7s; --continue and --abort restore them, or get them back later with git stash pop", e.Stash)
+	}
+	return b.String()
+}
+
+// UnmergedBranchesError reports branches selected for deletion that have not
+// landed on the base branch.
+type UnmergedBranchesError struct {
+	Base     string
+	Branches []string
+}
+
+// Error lists the unmerged branches and how to delete them anyway.
+func (e UnmergedBranchesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d branch(es) not merged into %s:", len(e.Branches), e.Base)
+	for _, name := range e.Branches {
+		fmt.Fprintf(&b, "\n  %s", name)
+	}
+	b.WriteString("\nRerun with --force to delete them; gitflow cleanup --undo can restore them")
+	return b.String()
+}
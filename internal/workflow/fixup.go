@@ -0,0 +1,190 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+// FixupOptions defines inputs for creating a fixup commit.
+type FixupOptions struct {
+	RepoPath string
+	All      bool
+	Target   string
+}
+
+// FixupResult reports the created fixup commit.
+type FixupResult struct {
+	Target git.Commit
+	Branch string
+	// Protected is the pattern the branch matched, if it is protected.
+	Protected string
+}
+
+// FixupCandidates lists commits on the current branch since the merge base,
+// optionally narrowed by a hash prefix or subject search.
+func FixupCandidates(cfg *config.Config, repoPath string, search string) ([]git.Commit, error) {
+	if repoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	client, err := git.NewClient(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := branchCommits(cfg, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchCommits(commits, search), nil
+}
+
+// Fixup creates a fixup commit aimed at a commit on the current branch.
+func Fixup(cfg *config.Config, opts FixupOptions) (*FixupResult, error) {
+	if opts.RepoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+	if strings.TrimSpace(opts.Target) == "" {
+		return nil, fmt.Errorf("fixup target is required")
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := branchCommits(cfg, client)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchCommits(commits, opts.Target)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no commit on this branch matches %q", opts.Target)
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("%d commits match %q, be more specific", len(matches), opts.Target)
+	}
+	target := matches[0]
+
+	if opts.All {
+		if err := client.AddAll(); err != nil {
+			return nil, err
+		}
+	}
+
+	hasStaged, err := client.HasStagedChanges()
+	if err != nil {
+		return nil, err
+	}
+	if !hasStaged {
+		return nil, fmt.Errorf("no staged changes to commit")
+	}
+
+	matcher, err := cfg.ProtectedMatcher()
+	if err != nil {
+		return nil, ConfigError{Err: err}
+	}
+
+	if err := client.CommitFixup(target.Hash); err != nil {
+		return nil, err
+	}
+
+	res := &FixupResult{Target: target}
+	if branch, err := client.CurrentBranch(); err == nil && branch != "HEAD" {
+		res.Branch = branch
+		res.Protected, _ = matcher.Match(branch)
+	}
+	return res, nil
+}
+
+func branchCommits(cfg *config.Config, client *git.Client) ([]git.Commit, error) {
+	base, err := baseRef(client, "origin", startBaseBranch(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBase, err := client.MergeBase(base, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base with %s: %w", base, err)
+	}
+
+	commits, err := client.CommitsBetween(mergeBase, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits on this branch since %s", base)
+	}
+	return commits, nil
+}
+
+// baseRef picks the ref a branch is compared with: <remote>/<base> when it
+// exists, unless the local base already contains it.
+func baseRef(client *git.Client, remote string, base string) (string, error) {
+	remoteRef := remote + "/" + base
+	hasRemote, err := client.BranchExists("refs/remotes/" + remoteRef)
+	if err != nil {
+		return "", err
+	}
+	hasLocal, err := client.BranchExists("refs/heads/" + base)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case hasRemote && hasLocal:
+		if ok, err := client.IsAncestor(remoteRef, base); err == nil && ok {
+			return base, nil
+		}
+		return remoteRef, nil
+	case hasRemote:
+		return remoteRef, nil
+	case hasLocal:
+		return base, nil
+	default:
+		return "", fmt.Errorf("base branch %s not found locally or on %s", base, remote)
+	}
+}
+
+func matchCommits(commits []git.Commit, search string) []git.Commit {
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return commits
+	}
+
+	if isHashPrefix(search) {
+		for _, c := range commits {
+			if strings.HasPrefix(c.Hash, strings.ToLower(search)) {
+				return []git.Commit{c}
+			}
+		}
+	}
+
+	needle := strings.ToLower(search)
+	var out []git.Commit
+	for _, c := range commits {
+		if strings.Contains(strings.ToLower(c.Subject), needle) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// isHashPrefix reports whether search can be an abbreviated commit hash:
+// at least four hex digits, the shortest abbreviation git accepts.
+func isHashPrefix(search string) bool {
+	if len(search) < 4 {
+		return false
+	}
+	for _, r := range strings.ToLower(search) {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+func setupFixupBranch(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	repo := setupCommitRepo(t)
+	base := defaultBranch(t, repo)
+
+	runGitCommitTest(t, repo, "checkout", "-b", "feature/login")
+	if err := os.WriteFile(filepath.Join(repo, "login.txt"), []byte("login"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitCommitTest(t, repo, "add", "-A")
+	runGitCommitTest(t, repo, "commit", "-m", "add login form")
+
+	if err := os.WriteFile(filepath.Join(repo, "logout.txt"), []byte("logout"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitCommitTest(t, repo, "add", "-A")
+	runGitCommitTest(t, repo, "commit", "-m", "add logout button")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = base
+	return repo, cfg
+}
+
+func TestFixupCandidatesFiltersBySubject(t *testing.T) {
+	repo, cfg := setupFixupBranch(t)
+
+	all, err := FixupCandidates(cfg, repo, "")
+	if err != nil {
+		t.Fatalf("FixupCandidates: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 branch commits got %d", len(all))
+	}
+
+	matches, err := FixupCandidates(cfg, repo, "LOGOUT")
+	if err != nil {
+		t.Fatalf("FixupCandidates: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Subject != "add logout button" {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+}
+
+func TestMatchCommitsNeedsHexForHashPrefix(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "add1234567", Subject: "tidy imports"},
+		{Hash: "fe00000000", Subject: "add login form"},
+	}
+
+	for search, want := range map[string]string{
+		"add":      "add login form",
+		"fe":       "",
+		"add1":     "tidy imports",
+		"login":    "add login form",
+		"ADD12345": "tidy imports",
+	} {
+		got := matchCommits(commits, search)
+		if want == "" {
+			if len(got) != 0 {
+				t.Fatalf("search %q: expected no match, got %+v", search, got)
+			}
+			continue
+		}
+		if len(got) != 1 || got[0].Subject != want {
+			t.Fatalf("search %q: expected %q, got %+v", search, want, got)
+		}
+	}
+}
+
+func TestFixupThenTidySquashesIntoTarget(t *testing.T) {
+	repo, cfg := setupFixupBranch(t)
+
+	if err := os.WriteFile(filepath.Join(repo, "login.txt"), []byte("login v2"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	out, err := Fixup(cfg, FixupOptions{
+		RepoPath: repo,
+		All:      true,
+		Target:   "login form",
+	})
+	if err != nil {
+		t.Fatalf("Fixup: %v", err)
+	}
+	if out.Target.Subject != "add login form" {
+		t.Fatalf("unexpected target %s", out.Target.Subject)
+	}
+
+	client, err := git.NewClient(repo)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	subject, err := client.Run("log", "-1", "--format=%s")
+	if err != nil {
+		t.Fatalf("log: %v", err)
+	}
+	if subject != "fixup! add login form" {
+		t.Fatalf("unexpected fixup subject %q", subject)
+	}
+
+	res, err := Tidy(cfg, TidyOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("Tidy: %v", err)
+	}
+	if res.Before != 3 || res.After != 2 {
+		t.Fatalf("expected 3 -> 2 commits got %d -> %d", res.Before, res.After)
+	}
+
+	log, err := client.Run("log", "--format=%s", cfg.Workflows.Start.BaseBranch+"..HEAD")
+	if err != nil {
+		t.Fatalf("log: %v", err)
+	}
+	if strings.Contains(log, "fixup!") {
+		t.Fatalf("expected fixup to be squashed, log: %s", log)
+	}
+}
+
+func TestTidyRejectsDirtyRepo(t *testing.T) {
+	repo, cfg := setupFixupBranch(t)
+
+	if err := os.WriteFile(filepath.Join(repo, "dirty.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if _, err := Tidy(cfg, TidyOptions{RepoPath: repo}); err == nil {
+		t.Fatalf("expected error for dirty repo")
+	}
+}
+
+func TestFixupUsesRemoteBaseAndReportsProtectedBranch(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	commitFile(t, repo, "upstream.txt", "upstream", "upstream change")
+	runGitCleanup(t, repo, nil, "push", "origin", "main")
+	runGitCleanup(t, repo, nil, "reset", "--hard", "HEAD~1")
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/x", "origin/main")
+	commitFile(t, repo, "x.txt", "x", "feature work")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Branches.Protected = []string{"feature/*"}
+
+	commits, err := FixupCandidates(cfg, repo, "")
+	if err != nil {
+		t.Fatalf("FixupCandidates: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Subject != "feature work" {
+		t.Fatalf("expected only the branch commit against origin/main, got %+v", commits)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "x.txt"), []byte("fixed"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out, err := Fixup(cfg, FixupOptions{RepoPath: repo, All: true, Target: commits[0].Hash})
+	if err != nil {
+		t.Fatalf("Fixup: %v", err)
+	}
+	if out.Branch != "feature/x" || out.Protected != "feature/*" {
+		t.Fatalf("expected protected branch to be reported, got %+v", out)
+	}
+}
@@ -21,15 +21,13 @@ type InitResult struct {
 
 // Init writes a gitflow config file to the repository.
 func Init(cfg *config.Config, opts InitOptions) (*InitResult, error) {
-	if opts.RepoPath == "" {
-		return nil, fmt.Errorf("repo path is required")
+	path, err := InitPath(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	path := filepath.Join(opts.RepoPath, ".gitflow.yml")
-
-	_, err := os.Stat(path)
-	if err == nil && !opts.Force {
-		return nil, fmt.Errorf(".gitflow.yml already exists, use --force to overwrite")
+	if err := config.ValidateStrict(cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
 	if err := config.WriteFile(path, cfg); err != nil {
@@ -38,3 +36,19 @@ func Init(cfg *config.Config, opts InitOptions) (*InitResult, error) {
 
 	return &InitResult{Path: path}, nil
 }
+
+// InitPath returns the config file Init writes, or an error when it exists
+// and Force is not set, so callers can check before asking questions.
+func InitPath(opts InitOptions) (string, error) {
+	if opts.RepoPath == "" {
+		return "", fmt.Errorf("repo path is required")
+	}
+
+	path := filepath.Join(opts.RepoPath, ".gitflow.yml")
+
+	_, err := os.Stat(path)
+	if err == nil && !opts.Force {
+		return "", fmt.Errorf(".gitflow.yml already exists, use --force to overwrite")
+	}
+	return path, nil
+}
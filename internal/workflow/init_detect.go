@@ -0,0 +1,209 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+// initHistoryDepth is how many recent commits init reads to judge whether
+// the repository already uses conventional commits.
+const initHistoryDepth = 50
+
+// initMaxScopes caps the scopes init proposes from history.
+const initMaxScopes = 10
+
+// conventionalTypes are the commit types init recognises in history.
+var conventionalTypes = []string{
+	"feat", "fix", "docs", "refactor", "test", "chore",
+	"perf", "build", "ci", "style", "revert",
+}
+
+var conventionalSubject = regexp.MustCompile(`^([a-z]+)(?:\(([^)]+)\))?!?: \S`)
+
+// InitDefaults are the settings gitflow init proposes for a repository.
+// DetectInit fills them from the repository; the wizard lets the user
+// change them before Config builds the file.
+type InitDefaults struct {
+	// Provider is github, gitlab or empty when origin is hosted elsewhere.
+	Provider string
+	// BaseURL is the API URL for self-hosted GitHub or GitLab instances.
+	BaseURL string
+	Owner   string
+	Repo    string
+
+	DefaultBranch string
+	// Develop names an existing develop branch, which new branches start from.
+	Develop string
+
+	Conventional bool
+	// Types lists the conventional types used by recent commits, most used
+	// first. Config adds any that commits.types does not already allow.
+	Types  []string
+	Scopes []string
+
+	// Commits is the number of recent commits read, and ConventionalCommits
+	// how many of them have a conventional header.
+	Commits             int
+	ConventionalCommits int
+}
+
+// DetectInit inspects the origin remote, its default branch and recent
+// history to propose init settings.
+func DetectInit(repoPath string) (*InitDefaults, error) {
+	if repoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	client, err := git.NewClient(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &InitDefaults{}
+	if raw, err := client.RemoteURL("origin"); err == nil {
+		if info, ok := git.ParseRemoteURL(raw); ok {
+			d.Provider, d.BaseURL = providerForHost(info.Host)
+			if d.Provider != "" {
+				d.Owner = info.Owner
+				d.Repo = info.Repo
+			}
+		}
+	}
+
+	d.DefaultBranch = detectDefaultBranch(client)
+	for _, ref := range []string{"refs/heads/develop", "refs/remotes/origin/develop"} {
+		if ok, _ := client.BranchExists(ref); ok {
+			d.Develop = "develop"
+			break
+		}
+	}
+
+	// A repository without commits has no history to read.
+	subjects, _ := client.RecentSubjects(initHistoryDepth)
+	d.Commits = len(subjects)
+	d.ConventionalCommits, d.Types, d.Scopes = conventionalUsage(subjects)
+	d.Conventional = d.Commits > 0 && d.ConventionalCommits*2 >= d.Commits
+
+	return d, nil
+}
+
+// Config builds the config init writes from the defaults.
+func (d *InitDefaults) Config() *config.Config {
+	cfg := config.Default()
+
+	if d.Provider != "" {
+		cfg.Provider = config.ProviderConfig{
+			Type:     d.Provider,
+			BaseURL:  d.BaseURL,
+			TokenEnv: strings.ToUpper(d.Provider) + "_TOKEN",
+			Owner:    d.Owner,
+			Repo:     d.Repo,
+		}
+	}
+
+	if d.DefaultBranch != "" {
+		cfg.Branches.MainBranch = d.DefaultBranch
+		cfg.Workflows.Start.BaseBranch = d.DefaultBranch
+		if !slices.Contains(cfg.Workflows.Cleanup.ProtectedBranches, d.DefaultBranch) {
+			cfg.Workflows.Cleanup.ProtectedBranches = append(cfg.Workflows.Cleanup.ProtectedBranches, d.DefaultBranch)
+		}
+	}
+	if d.Develop != "" {
+		cfg.Branches.DevelopBranch = d.Develop
+		cfg.Workflows.Start.BaseBranch = d.Develop
+	}
+
+	cfg.Commits.Conventional = d.Conventional
+	if d.Conventional {
+		for _, t := range d.Types {
+			if !slices.Contains(cfg.Commits.Types, t) {
+				cfg.Commits.Types = append(cfg.Commits.Types, t)
+			}
+		}
+		cfg.Commits.Scopes = d.Scopes
+	}
+	return cfg
+}
+
+// providerForHost maps a remote host to a provider type, with the API URL
+// for hosts other than github.com and gitlab.com.
+func providerForHost(host string) (string, string) {
+	host = strings.ToLower(host)
+	switch {
+	case host == "github.com":
+		return "github", ""
+	case host == "gitlab.com":
+		return "gitlab", ""
+	case strings.Contains(host, "github"):
+		return "github", "https://" + host + "/api/v3"
+	case strings.Contains(host, "gitlab"):
+		return "gitlab", "https://" + host + "/api/v4"
+	default:
+		return "", ""
+	}
+}
+
+// detectDefaultBranch prefers origin/HEAD, then a local main or master,
+// then the current branch.
+func detectDefaultBranch(client *git.Client) string {
+	if head, err := client.RemoteHead("origin"); err == nil && head != "" {
+		return head
+	}
+	for _, name := range []string{"main", "master"} {
+		if ok, _ := client.BranchExists("refs/heads/" + name); ok {
+			return name
+		}
+	}
+	if current, err := client.CurrentBranch(); err == nil && current != "" && current != "HEAD" {
+		return current
+	}
+	return "main"
+}
+
+// conventionalUsage counts subjects with a conventional header and returns
+// the types and scopes they use, most used first.
+func conventionalUsage(subjects []string) (int, []string, []string) {
+	count := 0
+	types := make(map[string]int)
+	scopes := make(map[string]int)
+	for _, subject := range subjects {
+		m := conventionalSubject.FindStringSubmatch(subject)
+		if m == nil || !slices.Contains(conventionalTypes, m[1]) {
+			continue
+		}
+		count++
+		types[m[1]]++
+		for _, scope := range strings.Split(m[2], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes[scope]++
+			}
+		}
+	}
+
+	scopeList := byUse(scopes)
+	if len(scopeList) > initMaxScopes {
+		scopeList = scopeList[:initMaxScopes]
+	}
+	return count, byUse(types), scopeList
+}
+
+// byUse returns the keys of uses, most used first and then by name.
+func byUse(uses map[string]int) []string {
+	out := make([]string, 0, len(uses))
+	for k := range uses {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if uses[out[i]] != uses[out[j]] {
+			return uses[out[i]] > uses[out[j]]
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
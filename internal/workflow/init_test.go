@@ -3,6 +3,8 @@ package workflow
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"gitflow/internal/config"
@@ -29,3 +31,61 @@ func TestInitWritesFile(t *testing.T) {
 		t.Fatalf("expected file to exist")
 	}
 }
+
+func TestDetectInit(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	runGitCleanup(t, repo, nil, "remote", "set-head", "origin", "main")
+	runGitCleanup(t, repo, nil, "remote", "set-url", "origin", "git@github.com:acme/widgets.git")
+	runGitCleanup(t, repo, nil, "branch", "develop")
+	for _, msg := range []string{"feat(api): add search", "fix(cli): handle empty args", "feat(api): page results", "perf(api): cache pages"} {
+		commitFile(t, repo, "notes.txt", msg, msg)
+	}
+
+	d, err := DetectInit(repo)
+	if err != nil {
+		t.Fatalf("DetectInit: %v", err)
+	}
+	if d.Provider != "github" || d.Owner != "acme" || d.Repo != "widgets" || d.BaseURL != "" {
+		t.Fatalf("unexpected provider detection: %+v", d)
+	}
+	if d.DefaultBranch != "main" || d.Develop != "develop" {
+		t.Fatalf("unexpected branches: %+v", d)
+	}
+	if d.Commits != 5 || d.ConventionalCommits != 4 || !d.Conventional {
+		t.Fatalf("unexpected history: %+v", d)
+	}
+	if strings.Join(d.Scopes, ",") != "api,cli" {
+		t.Fatalf("expected scopes api,cli, got %v", d.Scopes)
+	}
+
+	cfg := d.Config()
+	if !slices.Contains(cfg.Commits.Types, "perf") || !slices.Contains(cfg.Commits.Types, "chore") {
+		t.Fatalf("expected perf from history added to the default types, got %v", cfg.Commits.Types)
+	}
+	if cfg.Provider.TokenEnv != "GITHUB_TOKEN" || cfg.Workflows.Start.BaseBranch != "develop" || cfg.Branches.DevelopBranch != "develop" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestInitDefaultsSelfHostedAndPlainHistory(t *testing.T) {
+	provider, baseURL := providerForHost("gitlab.example.com")
+	if provider != "gitlab" || baseURL != "https://gitlab.example.com/api/v4" {
+		t.Fatalf("unexpected self-hosted detection: %s %s", provider, baseURL)
+	}
+	if provider, _ := providerForHost("git.example.com"); provider != "" {
+		t.Fatalf("expected unknown host to have no provider, got %s", provider)
+	}
+
+	count, types, scopes := conventionalUsage([]string{"Update readme", "feat: add x", "wip", "fixed it"})
+	if count != 1 || len(types) != 1 || len(scopes) != 0 {
+		t.Fatalf("unexpected usage: %d %v %v", count, types, scopes)
+	}
+
+	d := &InitDefaults{Provider: "gitlab", DefaultBranch: "trunk"}
+	if _, err := Init(d.Config(), InitOptions{RepoPath: t.TempDir()}); err == nil {
+		t.Fatalf("expected Init to reject a provider without owner and repo")
+	}
+}
@@ -24,11 +24,14 @@ type PRCreateOptions struct {
 
 	Reviewers []string
 	Labels    []string
+
+	AutostashOverride *bool
 }
 
 // PRCreateResult contains the created pull request.
 type PRCreateResult struct {
-	PR *types.PullRequest
+	PR        *types.PullRequest
+	Autostash *AutostashResult
 }
 
 // CreatePR creates a pull request from the current branch.
@@ -45,14 +48,26 @@ func CreatePR(cfg *config.Config, opts PRCreateOptions) (*PRCreateResult, error)
 		return nil, err
 	}
 
-	dirty, err := client.IsDirty()
+	autostashEnabled := cfg.Workflows.Autostash
+	if opts.AutostashOverride != nil {
+		autostashEnabled = *opts.AutostashOverride
+	}
+
+	stash, err := beginAutostash(client, autostashEnabled, "pr create")
 	if err != nil {
 		return nil, err
 	}
-	if dirty {
-		return nil, fmt.Errorf("working tree is not clean")
+
+	res, err := runCreatePR(client, cfg, opts)
+	if err != nil {
+		return nil, stash.restoreAfter(err)
 	}
 
+	res.Autostash = stash.restore()
+	return res, nil
+}
+
+func runCreatePR(client *git.Client, cfg *config.Config, opts PRCreateOptions) (*PRCreateResult, error) {
 	currentBranch, err := client.CurrentBranch()
 	if err != nil {
 		return nil, err
@@ -105,9 +120,14 @@ func CreatePR(cfg *config.Config, opts PRCreateOptions) (*PRCreateResult, error)
 		return nil, err
 	}
 
+	defaults := prDefaults(cfg, client, currentBranch)
 	title := strings.TrimSpace(opts.Title)
 	if title == "" {
-		title = defaultTitleFromBranch(currentBranch)
+		title = defaults.Title
+	}
+	description := opts.Description
+	if strings.TrimSpace(description) == "" {
+		description = defaults.Description
 	}
 
 	draft := cfg.Workflows.PR.Draft
@@ -130,7 +150,7 @@ func CreatePR(cfg *config.Config, opts PRCreateOptions) (*PRCreateResult, error)
 
 	pr, err := p.CreatePR(ctx, provider.CreatePROptions{
 		Title:       title,
-		Description: opts.Description,
+		Description: description,
 		HeadBranch:  currentBranch,
 		BaseBranch:  base,
 		Draft:       draft,
@@ -144,11 +164,13 @@ func CreatePR(cfg *config.Config, opts PRCreateOptions) (*PRCreateResult, error)
 	return &PRCreateResult{PR: pr}, nil
 }
 
-func defaultTitleFromBranch(branch string) string {
+// defaultTitleFromBranch derives a title from the slug of a branch named by
+// one of the configured branch types.
+func defaultTitleFromBranch(cfg *config.Config, branch string) string {
 	b := branch
-	b = strings.TrimPrefix(b, "feature/")
-	b = strings.TrimPrefix(b, "bugfix/")
-	b = strings.TrimPrefix(b, "hotfix/")
+	if parts, ok := parseBranchName(cfg, branch); ok {
+		b = parts.Slug
+	}
 	b = strings.ReplaceAll(b, "-", " ")
 	b = strings.ReplaceAll(b, "_", " ")
 	b = strings.TrimSpace(b)
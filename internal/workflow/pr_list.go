@@ -55,3 +55,35 @@ func ListPRs(cfg *config.Config, opts PRListOptions) (*PRListResult, error) {
 
 	return &PRListResult{PRs: prs}, nil
 }
+
+// branchPR returns the first pull request in state whose head is branch, or
+// nil.
+func branchPR(ctx context.Context, p provider.Provider, branch string, state string) (*types.PullRequest, error) {
+	prs, err := branchPRs(ctx, p, branch, state)
+	if err != nil || len(prs) == 0 {
+		return nil, err
+	}
+	return prs[0], nil
+}
+
+// branchPRs returns the pull requests in state whose head is branch.
+// Providers that can filter by head branch are asked for that branch only.
+func branchPRs(ctx context.Context, p provider.Provider, branch string, state string) ([]*types.PullRequest, error) {
+	var prs []*types.PullRequest
+	var err error
+	if l, ok := p.(provider.BranchPRLister); ok {
+		prs, err = l.ListBranchPRs(ctx, branch, state)
+	} else {
+		prs, err = p.ListPRs(ctx, state)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []*types.PullRequest
+	for _, pr := range prs {
+		if pr.HeadBranch == branch {
+			out = append(out, pr)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,323 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+	"gitflow/internal/provider"
+)
+
+// DefaultPromptFormat renders the branch, a dirty marker, ahead/behind counts
+// and the pull request number, for example "feature/login* ↑2 #41".
+const DefaultPromptFormat = `{{.Branch}}{{if .Dirty}}*{{end}}{{if .Ahead}} ↑{{.Ahead}}{{end}}{{if .Behind}} ↓{{.Behind}}{{end}}{{if .PR}} #{{.PR}}{{end}}`
+
+const (
+	// DefaultPromptBudget is how long a prompt may take when no budget is set.
+	DefaultPromptBudget = 250 * time.Millisecond
+	// DefaultPromptMaxAge is how long cached status is reused when no max age is set.
+	DefaultPromptMaxAge = 10 * time.Second
+
+	promptCacheFile = "cache"
+	// promptPRTTL is how long a pull request lookup, including a miss, is reused.
+	promptPRTTL = 5 * time.Minute
+)
+
+// PromptOptions defines inputs for rendering the shell prompt segment.
+type PromptOptions struct {
+	RepoPath string
+	Format   string
+
+	// Budget is the most time Prompt may spend. When it runs out the last
+	// cached result is printed instead, or nothing. It defaults to
+	// DefaultPromptBudget.
+	Budget time.Duration
+	// MaxAge bounds how long a cached result is reused while the index and
+	// HEAD are unchanged, so edits that have not touched the index show up.
+	// It defaults to DefaultPromptMaxAge.
+	MaxAge  time.Duration
+	NoCache bool
+}
+
+// PromptData holds the values available to prompt format templates.
+type PromptData struct {
+	Branch    string `json:"branch"`
+	Dirty     bool   `json:"dirty"`
+	Ahead     int    `json:"ahead"`
+	Behind    int    `json:"behind"`
+	Staged    int    `json:"staged"`
+	Unstaged  int    `json:"unstaged"`
+	Untracked int    `json:"untracked"`
+	Operation string `json:"operation,omitempty"`
+	PR        int    `json:"pr,omitempty"`
+}
+
+// promptCache is stored in .git/gitflow/cache. Status data is valid while the
+// index and HEAD modification times match; pull requests expire by age.
+type promptCache struct {
+	Index int64                     `json:"index_mtime"`
+	Head  int64                     `json:"head_mtime"`
+	At    time.Time                 `json:"at"`
+	Data  *PromptData               `json:"data,omitempty"`
+	PRs   map[string]promptPRLookup `json:"prs,omitempty"`
+}
+
+type promptPRLookup struct {
+	Number int       `json:"number"`
+	At     time.Time `json:"at"`
+}
+
+// Prompt renders a compact status line for shell prompts. It prints nothing
+// outside a repository and never waits longer than the budget.
+func Prompt(opts PromptOptions) (string, error) {
+	if strings.TrimSpace(opts.RepoPath) == "" {
+		return "", fmt.Errorf("repo path is required")
+	}
+	format := opts.Format
+	if format == "" {
+		format = DefaultPromptFormat
+	}
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt format: %w", err)
+	}
+
+	if opts.Budget <= 0 {
+		opts.Budget = DefaultPromptBudget
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = DefaultPromptMaxAge
+	}
+
+	gitDir, ok := findGitDir(opts.RepoPath)
+	if !ok {
+		return "", nil
+	}
+	deadline := time.Now().Add(opts.Budget)
+
+	index, head := fileMtime(filepath.Join(gitDir, "index")), fileMtime(filepath.Join(gitDir, "HEAD"))
+	cache := &promptCache{}
+	if !opts.NoCache {
+		cache = loadPromptCache(gitDir)
+	}
+	changed := false
+
+	data := cache.Data
+	fresh := data != nil && cache.Index == index && cache.Head == head && time.Since(cache.At) < opts.MaxAge
+	wantPR := strings.Contains(format, ".PR")
+	branch := headBranch(gitDir)
+
+	var prs chan int
+	if lookup, ok := cache.PRs[branch]; wantPR && branch != "" && (!ok || time.Since(lookup.At) > promptPRTTL) {
+		prs = make(chan int, 1)
+		go func() {
+			ctx, cancel := context.WithDeadline(context.Background(), deadline)
+			defer cancel()
+			// Failed lookups are cached as misses too; only running out of
+			// time leaves the branch to be tried again on the next prompt.
+			n, _ := lookupPromptPR(ctx, opts.RepoPath, branch)
+			if ctx.Err() == nil {
+				prs <- n
+			}
+		}()
+	}
+
+	if !fresh {
+		statuses := make(chan *PromptData, 1)
+		go func() {
+			if d, err := promptStatus(opts.RepoPath); err == nil {
+				statuses <- d
+			}
+		}()
+
+		select {
+		case d := <-statuses:
+			// git status may refresh the index, so key the entry on the times
+			// it left behind.
+			data = d
+			cache.Data, cache.At = d, time.Now()
+			cache.Index, cache.Head = fileMtime(filepath.Join(gitDir, "index")), fileMtime(filepath.Join(gitDir, "HEAD"))
+			changed = true
+		case <-time.After(time.Until(deadline)):
+		}
+	}
+
+	if prs != nil {
+		select {
+		case n := <-prs:
+			if cache.PRs == nil {
+				cache.PRs = make(map[string]promptPRLookup)
+			}
+			cache.PRs[branch] = promptPRLookup{Number: n, At: time.Now()}
+			changed = true
+		case <-time.After(time.Until(deadline)):
+		}
+	}
+
+	if data == nil {
+		return "", nil
+	}
+	if changed && !opts.NoCache {
+		_ = savePromptCache(gitDir, cache)
+	}
+
+	out := *data
+	out.PR = cache.PRs[out.Branch].Number
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, out); err != nil {
+		return "", fmt.Errorf("invalid prompt format: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// promptStatus runs the single git status call the prompt needs.
+func promptStatus(repoPath string) (*PromptData, error) {
+	client, err := git.NewClient(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := client.TreeStatus()
+	if err != nil {
+		return nil, err
+	}
+	op, _ := client.InProgressOperation()
+
+	branch := tree.Branch
+	if tree.Detached {
+		branch = fmt.Sprintf("(%.7s)", tree.Head)
+	}
+	return &PromptData{
+		Branch:    branch,
+		Dirty:     tree.Dirty(),
+		Ahead:     tree.Ahead,
+		Behind:    tree.Behind,
+		Staged:    tree.Staged,
+		Unstaged:  tree.Unstaged + tree.Conflicted,
+		Untracked: tree.Untracked,
+		Operation: op,
+	}, nil
+}
+
+// lookupPromptPR finds the number of the open pull request for branch, or 0.
+func lookupPromptPR(ctx context.Context, repoPath, branch string) (int, error) {
+	res, err := config.LoadFromDir(repoPath)
+	if err != nil {
+		return 0, err
+	}
+	if !provider.Enabled(res.Config) {
+		return 0, nil
+	}
+	pcfg, err := provider.FromAppConfig(res.Config)
+	if err != nil {
+		return 0, err
+	}
+	p, err := provider.New(pcfg)
+	if err != nil {
+		return 0, err
+	}
+	pr, err := branchPR(ctx, p, branch, "open")
+	if err != nil || pr == nil {
+		return 0, err
+	}
+	return pr.Number, nil
+}
+
+// findGitDir locates the git directory without starting git, following the
+// .git file used by worktrees and submodules.
+func findGitDir(start string) (string, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			if info.IsDir() {
+				return dotGit, true
+			}
+			raw, err := os.ReadFile(dotGit)
+			if err != nil {
+				return "", false
+			}
+			target := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(raw)), "gitdir:"))
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(dir, target)
+			}
+			return target, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// headBranch reads the checked out branch from HEAD, empty when detached.
+func headBranch(gitDir string) string {
+	raw, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	ref := strings.TrimSpace(string(raw))
+	if !strings.HasPrefix(ref, "ref: refs/heads/") {
+		return ""
+	}
+	return strings.TrimPrefix(ref, "ref: refs/heads/")
+}
+
+func fileMtime(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.ModTime().UnixNano()
+}
+
+func loadPromptCache(gitDir string) *promptCache {
+	cache := &promptCache{}
+	raw, err := os.ReadFile(filepath.Join(gitDir, "gitflow", promptCacheFile))
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(raw, cache); err != nil {
+		return &promptCache{}
+	}
+	return cache
+}
+
+// savePromptCache writes the cache through a temporary file so concurrent
+// prompts never read a partial file.
+func savePromptCache(gitDir string, cache *promptCache) error {
+	dir := filepath.Join(gitDir, "gitflow")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, promptCacheFile+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, promptCacheFile))
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPromptRendersAndCaches(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/prompt")
+	commitFile(t, repo, "p.txt", "p", "prompt work")
+
+	opts := PromptOptions{RepoPath: repo, Budget: 5 * time.Second}
+	line, err := Prompt(opts)
+	if err != nil {
+		t.Fatalf("Prompt: %v", err)
+	}
+	if line != "feature/prompt" {
+		t.Fatalf("prompt = %q, want feature/prompt", line)
+	}
+
+	cachePath := filepath.Join(repo, ".git", "gitflow", "cache")
+	raw, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatalf("expected prompt cache: %v", err)
+	}
+
+	// A cache entry whose index and HEAD times still match is used as is.
+	var cache promptCache
+	if err := json.Unmarshal(raw, &cache); err != nil {
+		t.Fatalf("decode cache: %v", err)
+	}
+	cache.Data.Branch = "from-cache"
+	if err := savePromptCache(filepath.Join(repo, ".git"), &cache); err != nil {
+		t.Fatalf("save cache: %v", err)
+	}
+	if line, _ := Prompt(opts); line != "from-cache" {
+		t.Fatalf("expected cached prompt, got %q", line)
+	}
+
+	// Staging a change rewrites the index and invalidates the entry.
+	if err := os.WriteFile(filepath.Join(repo, "p.txt"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitCleanup(t, repo, nil, "add", "p.txt")
+
+	opts.Format = "{{.Branch}}{{if .Dirty}}*{{end}} +{{.Staged}}"
+	if line, _ := Prompt(opts); line != "feature/prompt* +1" {
+		t.Fatalf("prompt after staging = %q", line)
+	}
+}
+
+func TestPromptOutsideRepoAndBadFormat(t *testing.T) {
+	if line, err := Prompt(PromptOptions{RepoPath: t.TempDir()}); err != nil || line != "" {
+		t.Fatalf("expected empty prompt outside a repo, got %q, %v", line, err)
+	}
+	if _, err := Prompt(PromptOptions{RepoPath: t.TempDir(), Format: "{{.Branch"}); err == nil {
+		t.Fatalf("expected invalid format error")
+	}
+}
+
+func TestPromptFallsBackToCacheWhenBudgetRunsOut(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	gitDir := filepath.Join(repo, ".git")
+
+	stale := &promptCache{Data: &PromptData{Branch: "stale"}, At: time.Now().Add(-time.Hour)}
+	if err := savePromptCache(gitDir, stale); err != nil {
+		t.Fatalf("save cache: %v", err)
+	}
+
+	line, err := Prompt(PromptOptions{RepoPath: repo, Budget: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("Prompt: %v", err)
+	}
+	if line != "stale" && line != "main" {
+		t.Fatalf("expected the stale cache or a fast result, got %q", line)
+	}
+}
@@ -7,14 +7,21 @@ import (
 	"gitflow/internal/git"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // StartOptions defines inputs for creating a new branch.
 type StartOptions struct {
+	// Kind is a branch type: feature, bugfix, hotfix or one from branches.types.
 	Kind     string
 	RepoPath string
 	Remote   string
 	Name     string
+	Issue    string
+	// Description is stored in the branch metadata; it defaults to Name.
+	Description string
+
+	AutostashOverride *bool
 }
 
 // StartResult reports the created branch details.
@@ -22,6 +29,9 @@ type StartResult struct {
 	BaseBranch string
 	NewBranch  string
 	Pushed     bool
+	Autostash  *AutostashResult
+	// Warnings reports options that did not make it into the branch name.
+	Warnings []string
 }
 
 // Start creates and optionally pushes a new branch.
@@ -41,22 +51,38 @@ func Start(cfg *config.Config, opts StartOptions) (*StartResult, error) {
 		return nil, err
 	}
 
-	dirty, err := client.IsDirty()
+	kind, err := findBranchType(cfg, opts.Kind)
+	if err != nil {
+		return nil, err
+	}
+	newBranch, err := buildBranchName(cfg, client, kind, opts.Issue, opts.Name)
 	if err != nil {
 		return nil, err
 	}
-	if dirty {
-		return nil, fmt.Errorf("working tree is not clean")
+
+	autostashEnabled := cfg.Workflows.Autostash
+	if opts.AutostashOverride != nil {
+		autostashEnabled = *opts.AutostashOverride
+	}
+
+	stash, err := beginAutostash(client, autostashEnabled, "start "+opts.Name)
+	if err != nil {
+		return nil, err
 	}
 
-	base := cfg.Workflows.Start.BaseBranch
-	if base == "" {
-		base = cfg.Branches.MainBranch
+	res, err := runStart(client, cfg, opts, kind.Base, newBranch)
+	if err != nil {
+		return nil, stash.restoreAfter(err)
 	}
-	if base == "" {
-		base = "main"
+
+	if strings.TrimSpace(opts.Issue) != "" && !issueField.MatchString(kind.Template) {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("branch type %s does not use {{.Issue}}, the issue is only recorded in the branch metadata", kind.Name))
 	}
+	res.Autostash = stash.restore()
+	return res, nil
+}
 
+func runStart(client *git.Client, cfg *config.Config, opts StartOptions, base string, newBranch string) (*StartResult, error) {
 	remoteExists, err := client.HasRemote(opts.Remote)
 	if err != nil {
 		return nil, err
@@ -71,18 +97,40 @@ func Start(cfg *config.Config, opts StartOptions) (*StartResult, error) {
 		}
 	}
 
-	if err := client.Pull(opts.Remote, base); err != nil {
+	// Branch types can start from different bases, so make sure the pull
+	// lands on the right one.
+	current, err := client.CurrentBranch()
+	if err != nil {
 		return nil, err
 	}
+	if current != base {
+		if err := client.Checkout(base); err != nil {
+			return nil, err
+		}
+	}
 
-	prefix := prefixForKind(cfg, opts.Kind)
-	slug := slugify(opts.Name)
-	newBranch := prefix + slug
+	if err := client.Pull(opts.Remote, base); err != nil {
+		return nil, err
+	}
 
 	if err := client.CheckoutNew(newBranch); err != nil {
 		return nil, err
 	}
 
+	description := strings.TrimSpace(opts.Description)
+	if description == "" {
+		description = strings.TrimSpace(opts.Name)
+	}
+	meta := git.BranchMeta{
+		Description: description,
+		Issue:       strings.TrimSpace(opts.Issue),
+		Parent:      base,
+		Created:     time.Now().UTC(),
+	}
+	if err := client.SetBranchMeta(newBranch, meta); err != nil {
+		return nil, err
+	}
+
 	pushed := false
 	if cfg.Workflows.Start.AutoPush {
 		if err := client.PushSetUpstream(opts.Remote, newBranch); err != nil {
@@ -99,24 +147,6 @@ func Start(cfg *config.Config, opts StartOptions) (*StartResult, error) {
 
 }
 
-func prefixForKind(cfg *config.Config, kind string) string {
-	switch kind {
-	case "bugfix":
-		if cfg.Branches.BugfixPrefix != "" {
-			return cfg.Branches.BugfixPrefix
-		}
-		return "bugfix/"
-	case "hotfix":
-		if cfg.Branches.HotfixPrefix != "" {
-			return cfg.Branches.HotfixPrefix
-		}
-		return "hotfix/"
-	default:
-		return "feature/"
-
-	}
-}
-
 var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
 
 func slugify(s string) string {
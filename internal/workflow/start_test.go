@@ -112,3 +112,67 @@ func TestStartRejectsDirtyRepo(t *testing.T) {
 		t.Fatalf("expected error for dirty repo")
 	}
 }
+
+func TestStartUsesBranchTypeBase(t *testing.T) {
+	_, repo := setupOriginAndClone(t)
+	runGit(t, repo, "checkout", "-b", "develop")
+	runGit(t, repo, "commit", "--allow-empty", "-m", "develop only")
+	runGit(t, repo, "push", "origin", "develop")
+	runGit(t, repo, "checkout", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Start.AutoPush = false
+	cfg.Branches.Types = []config.BranchType{{Name: "spike", BaseBranch: "develop"}}
+
+	res, err := Start(cfg, StartOptions{
+		Kind:     "spike",
+		RepoPath: repo,
+		Remote:   "origin",
+		Name:     "try caching",
+	})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if res.NewBranch != "spike/try-caching" || res.BaseBranch != "develop" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	client, _ := git.NewClient(repo)
+	subject, _ := client.Run("log", "-1", "--format=%s")
+	if subject != "develop only" {
+		t.Fatalf("expected spike to start from develop, head is %q", subject)
+	}
+	mainHead, _ := client.Run("log", "-1", "--format=%s", "main")
+	if mainHead != "initial" {
+		t.Fatalf("expected main to be untouched, head is %q", mainHead)
+	}
+}
+
+func TestStartWarnsWhenTemplateHasNoIssue(t *testing.T) {
+	_, repo := setupOriginAndClone(t)
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Start.AutoPush = false
+
+	res, err := Start(cfg, StartOptions{
+		Kind:     "feature",
+		RepoPath: repo,
+		Remote:   "origin",
+		Name:     "login page",
+		Issue:    "PROJ-9",
+	})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if len(res.Warnings) != 1 {
+		t.Fatalf("expected a warning about the unused issue, got %v", res.Warnings)
+	}
+
+	client, _ := git.NewClient(repo)
+	meta, _ := client.BranchMeta(res.NewBranch)
+	if meta.Issue != "PROJ-9" {
+		t.Fatalf("expected the issue in the branch metadata, got %q", meta.Issue)
+	}
+}
@@ -1,38 +1,229 @@
 package workflow
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
+
+	"gitflow/internal/config"
 	"gitflow/internal/git"
+	"gitflow/internal/provider"
+	"gitflow/pkg/types"
 )
 
+// StatusOptions defines inputs for inspecting a repository.
+type StatusOptions struct {
+	RepoPath string
+	Base     string
+	Remote   string
+
+	// WithPR looks up the branch's open pull request and its checks.
+	WithPR bool
+	// Short gathers only what Status.Short prints, skipping the base
+	// comparison, the release tag and the pull request.
+	Short bool
+}
+
 // Status captures current repository state.
 type Status struct {
 	RepoPath string
 	Branch   string
+	Detached bool
+	Head     string
 	Dirty    bool
+
+	Upstream     string
+	UpstreamGone bool
+	Ahead        int
+	Behind       int
+
+	Base       string
+	BaseAhead  int
+	BaseBehind int
+
+	Staged     int
+	Unstaged   int
+	Untracked  int
+	Conflicted int
+	Stashes    int
+
+	// Operation is the rebase, am, merge, cherry-pick, revert or bisect in
+	// progress, if any.
+	Operation string
+
+	PR     *types.PullRequest
+	Checks string
+
+	LatestTag  string
+	Unreleased int
+
+	Warnings []string
 }
 
 // GetStatus inspects a repo and returns its status.
-func GetStatus(repoPath string) (*Status, error) {
-	client, err := git.NewClient(repoPath)
+func GetStatus(cfg *config.Config, opts StatusOptions) (*Status, error) {
+	if strings.TrimSpace(opts.RepoPath) == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+	if opts.Remote == "" {
+		opts.Remote = "origin"
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
 	if err != nil {
 		return nil, err
 	}
 
-	branch, err := client.CurrentBranch()
+	tree, err := client.TreeStatus()
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine current branch: %w", err)
+		return nil, fmt.Errorf("failed to determine working tree state: %w", err)
+	}
+
+	s := &Status{
+		RepoPath:     opts.RepoPath,
+		Branch:       tree.Branch,
+		Detached:     tree.Detached,
+		Head:         tree.Head,
+		Dirty:        tree.Dirty(),
+		Upstream:     tree.Upstream,
+		UpstreamGone: tree.UpstreamGone,
+		Ahead:        tree.Ahead,
+		Behind:       tree.Behind,
+		Staged:       tree.Staged,
+		Unstaged:     tree.Unstaged,
+		Untracked:    tree.Untracked,
+		Conflicted:   tree.Conflicted,
+	}
+
+	if s.Stashes, err = client.StashCount(); err != nil {
+		return nil, err
+	}
+	if s.Operation, err = client.InProgressOperation(); err != nil {
+		return nil, err
 	}
 
-	dirty, err := client.IsDirty()
+	// Everything below compares commits, which an unborn branch has none of.
+	if s.Head == "" || opts.Short {
+		return s, nil
+	}
+
+	s.Base = statusBase(cfg, client, opts.Base, s.Branch)
+	if s.Branch != s.Base {
+		if ref, ok := resolveBaseRef(client, opts.Remote, s.Base); ok {
+			s.BaseAhead, s.BaseBehind, _ = client.AheadBehind("HEAD", ref)
+		}
+	}
+
+	_, s.LatestTag, err = latestVersion(client, cfg.Release.TagPrefix)
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine working tree state: %w", err)
+		return nil, err
+	}
+	if s.Unreleased, err = client.CountCommits(s.LatestTag, "HEAD"); err != nil {
+		return nil, err
+	}
+
+	if opts.WithPR && s.Branch != "" && provider.Enabled(cfg) {
+		if err := attachStatusPR(cfg, s); err != nil {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("pull request lookup failed: %v", err))
+		}
+	}
+
+	return s, nil
+}
+
+// statusBase picks the branch to compare against: the explicit base, the
+// parent recorded for the branch, or the configured start base.
+func statusBase(cfg *config.Config, client *git.Client, base, branch string) string {
+	if base = strings.TrimSpace(base); base != "" {
+		return base
+	}
+	if branch != "" {
+		if meta, err := client.BranchMeta(branch); err == nil && meta.Parent != "" {
+			return meta.Parent
+		}
+	}
+	return startBaseBranch(cfg)
+}
+
+// resolveBaseRef prefers the local base branch and falls back to its
+// remote-tracking branch.
+func resolveBaseRef(client *git.Client, remote, base string) (string, bool) {
+	for _, ref := range []string{"refs/heads/" + base, "refs/remotes/" + remote + "/" + base} {
+		if ok, _ := client.BranchExists(ref); ok {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+func attachStatusPR(cfg *config.Config, s *Status) error {
+	pcfg, err := provider.FromAppConfig(cfg)
+	if err != nil {
+		return err
+	}
+	p, err := provider.New(pcfg)
+	if err != nil {
+		return err
 	}
 
-	return &Status{
-		RepoPath: repoPath,
-		Branch:   branch,
-		Dirty:    dirty,
-	}, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if s.PR, err = branchPR(ctx, p, s.Branch, "open"); err != nil {
+		return err
+	}
+	if s.PR == nil {
+		return nil
+	}
 
+	// Checks belong to the pull request head, which the local branch may not
+	// have caught up with.
+	ref := s.PR.HeadSHA
+	if ref == "" {
+		ref = s.Head
+	}
+	if r, ok := p.(provider.CheckReporter); ok {
+		if s.Checks, err = r.CheckStatus(ctx, ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Short formats the status on one line for shell prompts, for example
+// "feature/login ↑2 ↓1 +3 !1 ?2 $1 REBASE". Counts that are zero are left out.
+func (s *Status) Short() string {
+	parts := []string{s.Branch}
+	if s.Detached {
+		head := s.Head
+		if len(head) > 7 {
+			head = head[:7]
+		}
+		parts[0] = "(" + head + ")"
+	}
+
+	counts := []struct {
+		mark string
+		n    int
+	}{
+		{"↑", s.Ahead},
+		{"↓", s.Behind},
+		{"+", s.Staged},
+		{"!", s.Unstaged + s.Conflicted},
+		{"?", s.Untracked},
+		{"$", s.Stashes},
+	}
+	for _, c := range counts {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%s%d", c.mark, c.n))
+		}
+	}
+	if s.UpstreamGone {
+		parts = append(parts, "gone")
+	}
+	if s.Operation != "" {
+		parts = append(parts, strings.ToUpper(s.Operation))
+	}
+	return strings.Join(parts, " ")
 }
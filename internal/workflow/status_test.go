@@ -1,9 +1,16 @@
 package workflow
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"gitflow/internal/config"
 )
 
 func setupRepo(t *testing.T) string {
@@ -28,7 +35,7 @@ func TestGetStatus(t *testing.T) {
 	dir := setupRepo(t)
 	defer os.RemoveAll(dir)
 
-	s, err := GetStatus(dir)
+	s, err := GetStatus(config.Default(), StatusOptions{RepoPath: dir})
 	if err != nil {
 		t.Fatalf("GetStatus: %v", err)
 	}
@@ -39,3 +46,129 @@ func TestGetStatus(t *testing.T) {
 		t.Fatalf("expected clean repo")
 	}
 }
+
+func TestGetStatusReportsTreeUpstreamBaseAndRelease(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+	runGitCleanup(t, repo, nil, "tag", "v1.0.0")
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/status")
+	commitFile(t, repo, "a.txt", "a", "first")
+	runGitCleanup(t, repo, nil, "push", "-u", "origin", "feature/status")
+	commitFile(t, repo, "b.txt", "b", "second")
+
+	if err := os.WriteFile(filepath.Join(repo, "stash.txt"), []byte("s"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitCleanup(t, repo, nil, "stash", "push", "--include-untracked")
+
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("staged"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitCleanup(t, repo, nil, "add", "a.txt")
+	if err := os.WriteFile(filepath.Join(repo, "b.txt"), []byte("unstaged"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Branches.MainBranch = "main"
+
+	s, err := GetStatus(cfg, StatusOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+
+	if s.Branch != "feature/status" || s.Upstream != "origin/feature/status" {
+		t.Fatalf("unexpected branch/upstream: %q %q", s.Branch, s.Upstream)
+	}
+	if s.Ahead != 1 || s.Behind != 0 {
+		t.Fatalf("upstream ahead/behind = %d/%d, want 1/0", s.Ahead, s.Behind)
+	}
+	if s.Base != "main" || s.BaseAhead != 2 || s.BaseBehind != 0 {
+		t.Fatalf("base = %s %d/%d, want main 2/0", s.Base, s.BaseAhead, s.BaseBehind)
+	}
+	if s.Staged != 1 || s.Unstaged != 1 || s.Untracked != 1 || !s.Dirty {
+		t.Fatalf("tree counts = %d staged %d unstaged %d untracked", s.Staged, s.Unstaged, s.Untracked)
+	}
+	if s.Stashes != 1 {
+		t.Fatalf("stashes = %d, want 1", s.Stashes)
+	}
+	if s.LatestTag != "v1.0.0" || s.Unreleased != 2 {
+		t.Fatalf("release = %s with %d unreleased, want v1.0.0 with 2", s.LatestTag, s.Unreleased)
+	}
+	if got := s.Short(); got != "feature/status ↑1 +1 !1 ?1 $1" {
+		t.Fatalf("Short() = %q", got)
+	}
+
+	short, err := GetStatus(cfg, StatusOptions{RepoPath: repo, Short: true})
+	if err != nil {
+		t.Fatalf("GetStatus short: %v", err)
+	}
+	if got := short.Short(); got != s.Short() {
+		t.Fatalf("short Short() = %q, want %q", got, s.Short())
+	}
+	if short.LatestTag != "" || short.Base != "" {
+		t.Fatalf("expected the short status to skip release and base, got %q %q", short.LatestTag, short.Base)
+	}
+}
+
+func TestGetStatusChecksThePullRequestHead(t *testing.T) {
+	var checked string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/check-runs") {
+			checked = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/acme/repo/commits/"), "/check-runs")
+			fmt.Fprint(w, `{"check_runs": [{"status": "completed", "conclusion": "success"}]}`)
+			return
+		}
+		fmt.Fprint(w, `[{"number": 4, "state": "open", "head": {"ref": "feature/checks", "sha": "abc123"}, "base": {"ref": "main"}}]`)
+	}))
+	defer server.Close()
+	t.Setenv("GITFLOW_TEST_TOKEN", "token")
+
+	repo := setupRepoForCleanup(t)
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/checks")
+
+	cfg := config.Default()
+	cfg.Provider = config.ProviderConfig{
+		Type:     "github",
+		BaseURL:  server.URL,
+		TokenEnv: "GITFLOW_TEST_TOKEN",
+		Owner:    "acme",
+		Repo:     "repo",
+	}
+
+	s, err := GetStatus(cfg, StatusOptions{RepoPath: repo, WithPR: true})
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if s.PR == nil || s.PR.Number != 4 || s.Checks != "success" {
+		t.Fatalf("unexpected pull request status: %+v %q %v", s.PR, s.Checks, s.Warnings)
+	}
+	if checked != "abc123" {
+		t.Fatalf("expected checks for the pull request head, got %q", checked)
+	}
+}
+
+func TestGetStatusReportsOperationInProgress(t *testing.T) {
+	repo := setupRepoForCleanup(t)
+
+	runGitCleanup(t, repo, nil, "checkout", "-b", "feature/conflict")
+	commitFile(t, repo, "README.md", "branch", "branch edit")
+	runGitCleanup(t, repo, nil, "checkout", "main")
+	commitFile(t, repo, "README.md", "main", "main edit")
+
+	cmd := exec.Command("git", "merge", "feature/conflict")
+	cmd.Dir = repo
+	_ = cmd.Run()
+
+	s, err := GetStatus(config.Default(), StatusOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if s.Operation != "merge" || s.Conflicted != 1 {
+		t.Fatalf("expected merge with one conflict, got %q and %d", s.Operation, s.Conflicted)
+	}
+}
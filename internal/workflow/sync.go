@@ -5,6 +5,7 @@ import (
 	"gitflow/internal/config"
 	"gitflow/internal/git"
 	"strings"
+	"time"
 )
 
 // SyncOptions defines inputs for syncing the current branch.
@@ -14,6 +15,7 @@ type SyncOptions struct {
 	StrategyOverride  string
 	AutoPushOverride  *bool
 	ForcePushOverride *bool
+	AutostashOverride *bool
 }
 
 // SyncResult reports the outcome of a sync operation.
@@ -21,8 +23,12 @@ type SyncResult struct {
 	BaseBranch    string
 	CurrentBranch string
 	Strategy      string
+	BaseRef       string
+	BaseUpdated   bool
 	Pushed        bool
 	ForcePushed   bool
+	Autostash     *AutostashResult
+	Warnings      []string
 }
 
 // Sync updates the current branch from the base branch.
@@ -40,31 +46,45 @@ func Sync(cfg *config.Config, opts SyncOptions) (*SyncResult, error) {
 		return nil, err
 	}
 
-	dirty, err := client.IsDirty()
+	pending, err := loadSyncState(client)
 	if err != nil {
 		return nil, err
 	}
+	if pending != nil {
+		return nil, fmt.Errorf("sync of %s is already in progress, run gitflow sync --continue or gitflow sync --abort", pending.Branch)
+	}
 
-	if dirty {
-		return nil, fmt.Errorf("working tree is not clean")
+	autostashEnabled := cfg.Workflows.Autostash
+	if opts.AutostashOverride != nil {
+		autostashEnabled = *opts.AutostashOverride
 	}
 
-	current, err := client.CurrentBranch()
+	stash, err := beginAutostash(client, autostashEnabled, "sync")
 	if err != nil {
 		return nil, err
 	}
 
-	base := cfg.Workflows.Start.BaseBranch
-	if base == "" {
-		base = cfg.Branches.MainBranch
+	res, err := runSync(client, cfg, opts, stash)
+	if err != nil {
+		// A sync left resumable keeps its stash until continue or abort.
+		if recorded, _ := loadSyncState(client); recorded != nil {
+			return nil, err
+		}
+		return nil, stash.restoreAfter(err)
 	}
 
-	if base == "" {
-		base = "main"
-	}
+	res.Autostash = stash.restore()
+	return res, nil
+}
 
-	if strings.TrimSpace(current) == strings.TrimSpace(base) {
-		return nil, fmt.Errorf("already on base branch %s", base)
+// runSync integrates the base into the current branch. A stash created for
+// the sync is recorded in the sync state so continue and abort can restore it.
+func runSync(client *git.Client, cfg *config.Config, opts SyncOptions, stash *autostash) (*SyncResult, error) {
+	base := startBaseBranch(cfg)
+
+	current, err := ensureRewritable(client, base)
+	if err != nil {
+		return nil, err
 	}
 
 	remoteExists, err := client.HasRemote(opts.Remote)
@@ -82,6 +102,9 @@ func Sync(cfg *config.Config, opts SyncOptions) (*SyncResult, error) {
 	if opts.StrategyOverride != "" {
 		strategy = opts.StrategyOverride
 	}
+	if strategy != "rebase" && strategy != "merge" {
+		return nil, fmt.Errorf("unsupported sync strategy: %s", strategy)
+	}
 
 	autoPush := cfg.Workflows.Sync.AutoPush
 	if opts.AutoPushOverride != nil {
@@ -93,53 +116,358 @@ func Sync(cfg *config.Config, opts SyncOptions) (*SyncResult, error) {
 		forcePush = *opts.ForcePushOverride
 	}
 
+	if err := checkProtectedPush(cfg, current, strategy, autoPush, forcePush); err != nil {
+		return nil, err
+	}
+
 	if err := client.Fetch(opts.Remote); err != nil {
 		return nil, err
 	}
-	if err := client.Checkout(base); err != nil {
+
+	sb, err := syncTarget(client, opts.Remote, base)
+	if err != nil {
 		return nil, err
 	}
-	if err := client.Pull(opts.Remote, base); err != nil {
+	target := sb.ref
+
+	origHead, err := client.HeadCommit()
+	if err != nil {
 		return nil, err
 	}
-	if err := client.Checkout(current); err != nil {
+
+	state := &SyncState{
+		Branch:    current,
+		Base:      base,
+		Target:    target,
+		Remote:    opts.Remote,
+		Strategy:  strategy,
+		OrigHead:  origHead,
+		AutoPush:  autoPush,
+		ForcePush: forcePush,
+		StartedAt: time.Now().UTC(),
+	}
+	if stash != nil {
+		state.StashHash = stash.hash
+		state.StashMessage = stash.message
+	}
+	if err := saveSyncState(client, state); err != nil {
 		return nil, err
 	}
 
+	var integrateErr error
 	switch strategy {
 	case "rebase":
-		if err := client.Rebase(base); err != nil {
+		integrateErr = client.Rebase(target)
+	case "merge":
+		integrateErr = client.Merge(target)
+	}
+	if integrateErr != nil {
+		return nil, stopSync(client, state, integrateErr)
+	}
+
+	res, err := finishSync(client, state)
+	if err != nil {
+		return nil, err
+	}
+	res.BaseUpdated = sb.updated
+	if sb.warning != "" {
+		res.Warnings = append(res.Warnings, sb.warning)
+	}
+	return res, nil
+}
+
+// checkProtectedPush refuses a sync that would force-push a branch listed in
+// branches.protected.
+func checkProtectedPush(cfg *config.Config, branch, strategy string, autoPush, forcePush bool) error {
+	if !autoPush || !forcePush || strategy != "rebase" {
+		return nil
+	}
+	matcher, err := cfg.ProtectedMatcher()
+	if err != nil {
+		return ConfigError{Err: err}
+	}
+	if pattern, ok := matcher.Match(branch); ok {
+		return fmt.Errorf("refusing to force-push protected branch %s (matches %q); sync with --merge or --no-push instead", branch, pattern)
+	}
+	return nil
+}
+
+// syncBase is the ref a sync integrates and what happened to the local base.
+type syncBase struct {
+	ref     string
+	updated bool
+	warning string
+}
+
+// syncTarget picks the ref to integrate without checking out the base branch.
+// The remote-tracking branch is used whenever it exists, so commits only on
+// the local base never end up on the synced branch. A local base that is only
+// behind is fast-forwarded when git allows it, and a diverged local base is
+// reported instead of guessed at.
+func syncTarget(client *git.Client, remote string, base string) (syncBase, error) {
+	remoteRef := remote + "/" + base
+
+	hasRemoteRef, err := client.BranchExists(remoteRef)
+	if err != nil {
+		return syncBase{}, err
+	}
+	hasLocal, err := client.BranchExists("refs/heads/" + base)
+	if err != nil {
+		return syncBase{}, err
+	}
+
+	if !hasRemoteRef {
+		if !hasLocal {
+			return syncBase{}, fmt.Errorf("base branch %s not found locally or on %s", base, remote)
+		}
+		return syncBase{ref: base}, nil
+	}
+	if !hasLocal {
+		return syncBase{ref: remoteRef}, nil
+	}
+
+	ahead, behind, err := client.AheadBehind(base, remoteRef)
+	if err != nil {
+		return syncBase{}, err
+	}
+
+	switch {
+	case ahead > 0 && behind > 0:
+		return syncBase{}, fmt.Errorf("local %s has diverged from %s (%d ahead, %d behind), reconcile it before syncing", base, remoteRef, ahead, behind)
+	case ahead > 0:
+		return syncBase{
+			ref:     remoteRef,
+			warning: fmt.Sprintf("local %s has %d commit(s) not on %s; they were left out, push %s to include them", base, ahead, remoteRef, base),
+		}, nil
+	case behind > 0:
+		if err := client.FastForwardBranch(remote, base); err != nil {
+			return syncBase{
+				ref:     remoteRef,
+				warning: fmt.Sprintf("could not fast-forward local %s to %s: %v", base, remoteRef, err),
+			}, nil
+		}
+		return syncBase{ref: remoteRef, updated: true}, nil
+	default:
+		return syncBase{ref: remoteRef}, nil
+	}
+}
+
+// SyncContinue resumes a sync that stopped on conflicts, including its pending push.
+func SyncContinue(cfg *config.Config, opts SyncOptions) (*SyncResult, error) {
+	if opts.RepoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := loadSyncState(client)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no sync in progress")
+	}
+
+	rebasing, err := client.RebaseInProgress()
+	if err != nil {
+		return nil, err
+	}
+	merging, err := client.MergeInProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	if rebasing || merging {
+		files, err := client.ConflictedFiles()
+		if err != nil {
 			return nil, err
 		}
-	case "merge":
-		if err := client.Merge(base); err != nil {
+		if len(files) > 0 {
+			return nil, conflictError(state, files)
+		}
+
+		var continueErr error
+		if rebasing {
+			continueErr = client.RebaseContinue()
+		} else {
+			continueErr = client.MergeContinue()
+		}
+		if continueErr != nil {
+			files, err := client.ConflictedFiles()
+			if err == nil && len(files) > 0 {
+				return nil, conflictError(state, files)
+			}
+			return nil, continueErr
+		}
+	}
+
+	current, err := client.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if current != state.Branch {
+		return nil, fmt.Errorf("expected to be on %s to continue sync, found %s", state.Branch, current)
+	}
+
+	res, err := finishSync(client, state)
+	if err != nil {
+		return nil, err
+	}
+	res.Autostash = resumeAutostash(client, state.StashHash, state.StashMessage).restore()
+	return res, nil
+}
+
+// SyncAbort rolls back an in-progress sync and restores the original branch tip.
+func SyncAbort(cfg *config.Config, opts SyncOptions) (*SyncResult, error) {
+	if opts.RepoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := loadSyncState(client)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no sync in progress")
+	}
+
+	if err := abortOperation(client); err != nil {
+		return nil, err
+	}
+
+	current, err := client.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if current != state.Branch {
+		if err := client.Checkout(state.Branch); err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := client.HeadCommit()
+	if err != nil {
+		return nil, err
+	}
+	if head != state.OrigHead {
+		if err := client.ResetKeep(state.OrigHead); err != nil {
 			return nil, err
 		}
-	default:
-		return nil, fmt.Errorf("unsupported sync strategy: %s", strategy)
 	}
 
+	if err := clearSyncState(client); err != nil {
+		return nil, err
+	}
+
+	return &SyncResult{
+		BaseBranch:    state.Base,
+		CurrentBranch: state.Branch,
+		Strategy:      state.Strategy,
+		Autostash:     resumeAutostash(client, state.StashHash, state.StashMessage).restore(),
+	}, nil
+}
+
+func finishSync(client *git.Client, state *SyncState) (*SyncResult, error) {
 	pushed := false
 	forcePushed := false
-	if autoPush {
+	if state.AutoPush {
 		useForce := false
-		if strategy == "rebase" && forcePush {
+		if state.Strategy == "rebase" && state.ForcePush {
 			useForce = true
 		}
 
-		if err := client.Push(opts.Remote, current, useForce); err != nil {
-			return nil, err
+		if err := client.Push(state.Remote, state.Branch, useForce); err != nil {
+			return nil, fmt.Errorf("%w\nRun gitflow sync --continue to retry the push", err)
 		}
 
 		pushed = true
 		forcePushed = useForce
 	}
 
+	if err := clearSyncState(client); err != nil {
+		return nil, err
+	}
+
 	return &SyncResult{
-		BaseBranch:    base,
-		CurrentBranch: current,
-		Strategy:      strategy,
+		BaseBranch:    state.Base,
+		BaseRef:       state.Target,
+		CurrentBranch: state.Branch,
+		Strategy:      state.Strategy,
 		Pushed:        pushed,
 		ForcePushed:   forcePushed,
 	}, nil
 }
+
+// stopSync keeps the sync state when the integration stopped on conflicts
+// and otherwise rolls back so the branch is left as it was.
+func stopSync(client *git.Client, state *SyncState, cause error) error {
+	files, err := client.ConflictedFiles()
+	if err == nil && len(files) > 0 {
+		return conflictError(state, files)
+	}
+
+	_ = abortOperation(client)
+	_ = clearSyncState(client)
+	return cause
+}
+
+func abortOperation(client *git.Client) error {
+	rebasing, err := client.RebaseInProgress()
+	if err != nil {
+		return err
+	}
+	if rebasing {
+		return client.RebaseAbort()
+	}
+
+	merging, err := client.MergeInProgress()
+	if err != nil {
+		return err
+	}
+	if merging {
+		return client.MergeAbort()
+	}
+	return nil
+}
+
+func conflictError(state *SyncState, files []string) error {
+	return SyncConflictError{
+		Branch:   state.Branch,
+		Base:     state.Target,
+		Strategy: state.Strategy,
+		Files:    files,
+		Stash:    state.StashHash,
+	}
+}
+
+// ensureRewritable applies the guardrails shared by workflows that rewrite
+// the current branch and returns the current branch name.
+func ensureRewritable(client *git.Client, base string) (string, error) {
+	dirty, err := client.IsDirty()
+	if err != nil {
+		return "", err
+	}
+
+	if dirty {
+		return "", fmt.Errorf("working tree is not clean")
+	}
+
+	current, err := client.CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(current) == strings.TrimSpace(base) {
+		return "", fmt.Errorf("already on base branch %s", base)
+	}
+
+	return current, nil
+}
@@ -0,0 +1,256 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+const (
+	// SyncUpdated marks a branch that was rebased or merged onto the base.
+	SyncUpdated = "updated"
+	// SyncUpToDate marks a branch that already contained the base.
+	SyncUpToDate = "up-to-date"
+	// SyncConflicted marks a branch skipped because it would conflict.
+	SyncConflicted = "conflicted"
+	// SyncPushFailed marks a branch that was updated locally but could not be
+	// pushed.
+	SyncPushFailed = "push-failed"
+	// SyncSkipped marks a branch skipped for another reason.
+	SyncSkipped = "skipped"
+)
+
+// SyncAllResult reports the outcome of syncing every feature branch.
+type SyncAllResult struct {
+	BaseBranch string
+	BaseRef    string
+	Strategy   string
+	Original   string
+	Branches   []SyncBranchResult
+	Autostash  *AutostashResult
+	Warnings   []string
+}
+
+// SyncBranchResult reports the outcome for a single branch.
+type SyncBranchResult struct {
+	Name   string
+	Status string
+	Detail string
+	Pushed bool
+}
+
+// SyncAll rebases or merges every local branch named after a configured
+// branch type onto that type's base, skipping branches that would conflict,
+// and then returns to the branch the user started on.
+func SyncAll(cfg *config.Config, opts SyncOptions) (*SyncAllResult, error) {
+	if opts.RepoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+	if opts.Remote == "" {
+		opts.Remote = "origin"
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := loadSyncState(client)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, fmt.Errorf("sync of %s is already in progress, run gitflow sync --continue or gitflow sync --abort", pending.Branch)
+	}
+
+	base := startBaseBranch(cfg)
+
+	remoteExists, err := client.HasRemote(opts.Remote)
+	if err != nil {
+		return nil, err
+	}
+	if !remoteExists {
+		return nil, fmt.Errorf("remote %s not found", opts.Remote)
+	}
+
+	strategy := cfg.Workflows.Sync.Strategy
+	if strategy == "" {
+		strategy = "rebase"
+	}
+	if opts.StrategyOverride != "" {
+		strategy = opts.StrategyOverride
+	}
+	if strategy != "rebase" && strategy != "merge" {
+		return nil, fmt.Errorf("unsupported sync strategy: %s", strategy)
+	}
+
+	autoPush := cfg.Workflows.Sync.AutoPush
+	if opts.AutoPushOverride != nil {
+		autoPush = *opts.AutoPushOverride
+	}
+	forcePush := cfg.Workflows.Sync.ForcePush
+	if opts.ForcePushOverride != nil {
+		forcePush = *opts.ForcePushOverride
+	}
+	autostashEnabled := cfg.Workflows.Autostash
+	if opts.AutostashOverride != nil {
+		autostashEnabled = *opts.AutostashOverride
+	}
+
+	original, err := client.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := client.LocalBranchNames()
+	if err != nil {
+		return nil, err
+	}
+	branches := typedBranches(cfg, names, base)
+
+	result := &SyncAllResult{
+		BaseBranch: base,
+		Strategy:   strategy,
+		Original:   original,
+	}
+
+	// A dirty tree without autostash fails here, before any branch is touched.
+	stash, err := beginAutostash(client, autostashEnabled, "sync --all")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Fetch(opts.Remote); err != nil {
+		return nil, stash.restoreAfter(err)
+	}
+
+	sb, err := syncTarget(client, opts.Remote, base)
+	if err != nil {
+		return nil, stash.restoreAfter(err)
+	}
+	result.BaseRef = sb.ref
+	if sb.warning != "" {
+		result.Warnings = append(result.Warnings, sb.warning)
+	}
+	targets := map[string]string{base: sb.ref}
+
+	for _, b := range branches {
+		if err := checkProtectedPush(cfg, b.name, strategy, autoPush, forcePush); err != nil {
+			result.Branches = append(result.Branches, SyncBranchResult{Name: b.name, Status: SyncSkipped, Detail: "protected branch, force-push refused"})
+			continue
+		}
+		t, ok := targets[b.base]
+		if !ok {
+			sb, err := syncTarget(client, opts.Remote, b.base)
+			if err != nil {
+				result.Branches = append(result.Branches, SyncBranchResult{Name: b.name, Status: SyncSkipped, Detail: err.Error()})
+				continue
+			}
+			if sb.warning != "" {
+				result.Warnings = append(result.Warnings, sb.warning)
+			}
+			t = sb.ref
+			targets[b.base] = t
+		}
+		result.Branches = append(result.Branches, syncOneBranch(client, b.name, t, strategy, opts.Remote, autoPush, forcePush))
+	}
+
+	if err := client.Checkout(original); err != nil {
+		return nil, stash.restoreAfter(err)
+	}
+	result.Autostash = stash.restore()
+
+	return result, nil
+}
+
+func syncOneBranch(client *git.Client, branch, target, strategy, remote string, autoPush, forcePush bool) SyncBranchResult {
+	res := SyncBranchResult{Name: branch}
+
+	_, behind, err := client.AheadBehind(branch, target)
+	if err != nil {
+		res.Status = SyncSkipped
+		res.Detail = err.Error()
+		return res
+	}
+	if behind == 0 {
+		res.Status = SyncUpToDate
+		return res
+	}
+
+	if err := client.Checkout(branch); err != nil {
+		res.Status = SyncSkipped
+		res.Detail = "could not check out branch"
+		return res
+	}
+
+	var integrateErr error
+	switch strategy {
+	case "rebase":
+		integrateErr = client.Rebase(target)
+	case "merge":
+		integrateErr = client.Merge(target)
+	}
+	if integrateErr != nil {
+		files, _ := client.ConflictedFiles()
+		_ = abortOperation(client)
+		res.Status = SyncConflicted
+		if len(files) > 0 {
+			res.Detail = strings.Join(files, ", ")
+		} else {
+			res.Detail = strategy + " failed and was aborted"
+		}
+		return res
+	}
+
+	res.Status = SyncUpdated
+	res.Detail = fmt.Sprintf("%d commit(s) from %s", behind, target)
+
+	if autoPush {
+		onRemote, err := client.BranchExists(remote + "/" + branch)
+		if err == nil && onRemote {
+			useForce := strategy == "rebase" && forcePush
+			if err := client.Push(remote, branch, useForce); err != nil {
+				res.Status = SyncPushFailed
+				res.Detail += fmt.Sprintf(", push failed: %v", err)
+			} else {
+				res.Pushed = true
+			}
+		}
+	}
+
+	return res
+}
+
+// syncAllBranch is a branch sync --all updates and the base it syncs onto.
+type syncAllBranch struct {
+	name string
+	base string
+}
+
+// typedBranches picks the branches whose names match a branch type template,
+// each with its type's base. Branches that are themselves a base are left out.
+func typedBranches(cfg *config.Config, names []string, base string) []syncAllBranch {
+	bases := map[string]bool{base: true}
+	for _, t := range branchTypes(cfg) {
+		bases[t.Base] = true
+	}
+
+	var out []syncAllBranch
+	for _, name := range names {
+		if bases[name] {
+			continue
+		}
+		parts, ok := parseBranchName(cfg, name)
+		if !ok {
+			continue
+		}
+		b := syncAllBranch{name: name, base: base}
+		if t, err := findBranchType(cfg, parts.Type); err == nil && t.Base != "" {
+			b.base = t.Base
+		}
+		out = append(out, b)
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+func TestSyncAllUpdatesSkipsConflictsAndReturns(t *testing.T) {
+	_, a, b := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/clean")
+	commitFile(t, a, "clean.txt", "clean", "clean feature")
+
+	runGitSync(t, a, "checkout", "-b", "feature/conflict", "main")
+	commitFile(t, a, "README.md", "feature", "conflicting feature")
+
+	runGitSync(t, a, "checkout", "-b", "chore/deps", "main")
+	commitFile(t, a, "deps.txt", "deps", "bump deps")
+
+	runGitSync(t, a, "checkout", "-b", "experiment", "main")
+	commitFile(t, a, "exp.txt", "exp", "experiment")
+
+	runGitSync(t, a, "checkout", "feature/clean")
+
+	commitFile(t, b, "README.md", "main", "main readme")
+	runGitSync(t, b, "push", "origin", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.AutoPush = false
+	cfg.Branches.Types = []config.BranchType{{Name: "chore", Template: "chore/{{.Slug}}"}}
+
+	out, err := SyncAll(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("SyncAll: %v", err)
+	}
+
+	statuses := make(map[string]string)
+	for _, br := range out.Branches {
+		statuses[br.Name] = br.Status
+	}
+	if statuses["feature/clean"] != SyncUpdated {
+		t.Fatalf("expected feature/clean updated, got %q", statuses["feature/clean"])
+	}
+	if statuses["chore/deps"] != SyncUpdated {
+		t.Fatalf("expected branch of a configured type updated, got %q", statuses["chore/deps"])
+	}
+	if statuses["feature/conflict"] != SyncConflicted {
+		t.Fatalf("expected feature/conflict conflicted, got %q", statuses["feature/conflict"])
+	}
+	if _, ok := statuses["experiment"]; ok {
+		t.Fatalf("expected branch without a configured type to be ignored")
+	}
+
+	client, _ := git.NewClient(a)
+	current, _ := client.CurrentBranch()
+	if current != "feature/clean" {
+		t.Fatalf("expected to return to feature/clean, got %s", current)
+	}
+	rebasing, _ := client.RebaseInProgress()
+	if rebasing {
+		t.Fatalf("expected conflicted rebase to be aborted")
+	}
+}
+
+func TestSyncAllFailsWhenDirty(t *testing.T) {
+	_, a, _ := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/one")
+	if err := os.WriteFile(filepath.Join(a, "wip.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+
+	if _, err := SyncAll(cfg, SyncOptions{RepoPath: a, Remote: "origin"}); err == nil || !strings.Contains(err.Error(), "not clean") {
+		t.Fatalf("expected one dirty tree error, got %v", err)
+	}
+}
+
+func TestSyncAllReportsPushFailures(t *testing.T) {
+	origin, a, b := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/rejected")
+	commitFile(t, a, "f.txt", "f", "feature work")
+	runGitSync(t, a, "push", "-u", "origin", "feature/rejected")
+	commitFile(t, b, "m.txt", "m", "main advance")
+	runGitSync(t, b, "push", "origin", "main")
+
+	hook := filepath.Join(origin, "hooks", "pre-receive")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatalf("write hook: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.AutoPush = true
+	cfg.Workflows.Sync.ForcePush = true
+
+	out, err := SyncAll(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("SyncAll: %v", err)
+	}
+	if len(out.Branches) != 1 || out.Branches[0].Status != SyncPushFailed || out.Branches[0].Pushed {
+		t.Fatalf("expected push-failed, got %+v", out.Branches)
+	}
+}
+
+func TestTypedBranchesUseTheirTypeBase(t *testing.T) {
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Branches.Types = []config.BranchType{{Name: "release", Template: "release/{{.Slug}}", BaseBranch: "develop"}}
+
+	got := typedBranches(cfg, []string{"main", "develop", "feature/a", "release/1.2", "scratch"}, "main")
+	want := []syncAllBranch{{name: "feature/a", base: "main"}, {name: "release/1.2", base: "develop"}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gitflow/internal/git"
+)
+
+const syncStateFile = "sync-state"
+
+// SyncState records an in-flight sync so it can be continued or aborted.
+type SyncState struct {
+	Branch    string    `json:"branch"`
+	Base      string    `json:"base"`
+	Target    string    `json:"target"`
+	Remote    string    `json:"remote"`
+	Strategy  string    `json:"strategy"`
+	OrigHead  string    `json:"orig_head"`
+	AutoPush  bool      `json:"auto_push"`
+	ForcePush bool      `json:"force_push"`
+	StartedAt time.Time `json:"started_at"`
+
+	StashHash    string `json:"stash_hash,omitempty"`
+	StashMessage string `json:"stash_message,omitempty"`
+}
+
+// gitflowDir returns the gitflow state directory inside the git directory.
+func gitflowDir(client *git.Client) (string, error) {
+	gitDir, err := client.GitDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, "gitflow"), nil
+}
+
+func syncStatePath(client *git.Client) (string, error) {
+	dir, err := gitflowDir(client)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, syncStateFile), nil
+}
+
+// loadSyncState returns the recorded sync state or nil when none exists.
+func loadSyncState(client *git.Client) (*SyncState, error) {
+	path, err := syncStatePath(client)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+
+	var state SyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state at %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func saveSyncState(client *git.Client, state *SyncState) error {
+	path, err := syncStatePath(client)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
+
+func clearSyncState(client *git.Client) error {
+	path, err := syncStatePath(client)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove sync state: %w", err)
+	}
+	return nil
+}
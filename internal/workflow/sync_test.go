@@ -1,9 +1,11 @@
 package workflow
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"gitflow/internal/config"
@@ -140,3 +142,230 @@ func TestSyncRejectsDirtyRepo(t *testing.T) {
 		t.Fatalf("expected error for dirty repo")
 	}
 }
+
+func setupSyncConflict(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	_, a, b := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/conflict")
+	if err := os.WriteFile(filepath.Join(a, "README.md"), []byte("feature"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, a, "commit", "-am", "feature readme")
+	runGitSync(t, a, "push", "-u", "origin", "feature/conflict")
+
+	if err := os.WriteFile(filepath.Join(b, "README.md"), []byte("main"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, b, "commit", "-am", "main readme")
+	runGitSync(t, b, "push", "origin", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.Strategy = "rebase"
+	cfg.Workflows.Sync.AutoPush = true
+	cfg.Workflows.Sync.ForcePush = true
+	return a, cfg
+}
+
+func TestSyncConflictRecordsStateAndAbortRestores(t *testing.T) {
+	repo, cfg := setupSyncConflict(t)
+
+	client, err := git.NewClient(repo)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	before, _ := client.HeadCommit()
+
+	_, err = Sync(cfg, SyncOptions{RepoPath: repo, Remote: "origin"})
+	var conflict SyncConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected SyncConflictError, got %v", err)
+	}
+	if len(conflict.Files) != 1 || conflict.Files[0] != "README.md" {
+		t.Fatalf("unexpected conflicted files: %v", conflict.Files)
+	}
+
+	state, err := loadSyncState(client)
+	if err != nil || state == nil {
+		t.Fatalf("expected sync state to be recorded, err %v", err)
+	}
+
+	if _, err := Sync(cfg, SyncOptions{RepoPath: repo, Remote: "origin"}); err == nil {
+		t.Fatalf("expected second sync to be refused while one is in progress")
+	}
+
+	if _, err := SyncAbort(cfg, SyncOptions{RepoPath: repo}); err != nil {
+		t.Fatalf("SyncAbort: %v", err)
+	}
+
+	after, _ := client.HeadCommit()
+	if after != before {
+		t.Fatalf("expected head restored to %s got %s", before, after)
+	}
+	rebasing, _ := client.RebaseInProgress()
+	if rebasing {
+		t.Fatalf("expected rebase to be aborted")
+	}
+	state, _ = loadSyncState(client)
+	if state != nil {
+		t.Fatalf("expected sync state to be cleared")
+	}
+}
+
+func TestSyncContinueAfterResolvingPushes(t *testing.T) {
+	repo, cfg := setupSyncConflict(t)
+
+	if _, err := Sync(cfg, SyncOptions{RepoPath: repo, Remote: "origin"}); err == nil {
+		t.Fatalf("expected conflict")
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("resolved"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, repo, "add", "README.md")
+
+	out, err := SyncContinue(cfg, SyncOptions{RepoPath: repo})
+	if err != nil {
+		t.Fatalf("SyncContinue: %v", err)
+	}
+	if !out.Pushed || !out.ForcePushed {
+		t.Fatalf("expected pending force push to run")
+	}
+
+	client, _ := git.NewClient(repo)
+	remoteRef, _ := client.Run("rev-parse", "origin/feature/conflict")
+	headRef, _ := client.HeadCommit()
+	if remoteRef != headRef {
+		t.Fatalf("expected remote branch to match head after continue")
+	}
+}
+
+func TestSyncWorksWhenBaseCheckedOutInWorktree(t *testing.T) {
+	_, a, b := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/wt")
+	if err := os.WriteFile(filepath.Join(a, "wt.txt"), []byte("wt"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, a, "add", ".")
+	runGitSync(t, a, "commit", "-m", "worktree feature")
+	runGitSync(t, a, "worktree", "add", filepath.Join(t.TempDir(), "main-wt"), "main")
+
+	if err := os.WriteFile(filepath.Join(b, "main.txt"), []byte("main"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, b, "add", ".")
+	runGitSync(t, b, "commit", "-m", "main advance")
+	runGitSync(t, b, "push", "origin", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.AutoPush = false
+
+	out, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if out.BaseRef != "origin/main" {
+		t.Fatalf("expected sync onto origin/main got %s", out.BaseRef)
+	}
+	if out.BaseUpdated || len(out.Warnings) != 1 || !strings.Contains(out.Warnings[0], "could not fast-forward local main") {
+		t.Fatalf("expected a warning that main was not fast-forwarded, got %v", out.Warnings)
+	}
+
+	client, _ := git.NewClient(a)
+	current, _ := client.CurrentBranch()
+	if current != "feature/wt" {
+		t.Fatalf("expected to stay on feature/wt got %s", current)
+	}
+	_, behind, err := client.AheadBehind("HEAD", "origin/main")
+	if err != nil || behind != 0 {
+		t.Fatalf("expected branch to include origin/main, behind %d err %v", behind, err)
+	}
+}
+
+func TestSyncLeavesOutUnpushedLocalBaseCommits(t *testing.T) {
+	_, a, _ := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "feature/clean")
+	runGitSync(t, a, "checkout", "main")
+	if err := os.WriteFile(filepath.Join(a, "local.txt"), []byte("local"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, a, "add", ".")
+	runGitSync(t, a, "commit", "-m", "unpushed main commit")
+	runGitSync(t, a, "checkout", "feature/clean")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.AutoPush = false
+
+	out, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if out.BaseRef != "origin/main" {
+		t.Fatalf("expected sync onto origin/main got %s", out.BaseRef)
+	}
+	if len(out.Warnings) != 1 || !strings.Contains(out.Warnings[0], "1 commit(s) not on origin/main") {
+		t.Fatalf("expected a warning about the unpushed main commit, got %v", out.Warnings)
+	}
+	if _, err := os.Stat(filepath.Join(a, "local.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected the unpushed main commit to stay off the branch, stat err %v", err)
+	}
+}
+
+func TestSyncReportsDivergedLocalBase(t *testing.T) {
+	_, a, b := setupTwoClones(t)
+
+	if err := os.WriteFile(filepath.Join(a, "local.txt"), []byte("local"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, a, "add", ".")
+	runGitSync(t, a, "commit", "-m", "unpushed main commit")
+	runGitSync(t, a, "checkout", "-b", "feature/d")
+
+	if err := os.WriteFile(filepath.Join(b, "remote.txt"), []byte("remote"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	runGitSync(t, b, "add", ".")
+	runGitSync(t, b, "commit", "-m", "remote main commit")
+	runGitSync(t, b, "push", "origin", "main")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.AutoPush = false
+
+	_, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err == nil || !strings.Contains(err.Error(), "diverged") {
+		t.Fatalf("expected diverged base error, got %v", err)
+	}
+}
+
+func TestSyncRefusesForcePushOnProtectedBranch(t *testing.T) {
+	_, a, _ := setupTwoClones(t)
+
+	runGitSync(t, a, "checkout", "-b", "env/prod")
+
+	cfg := config.Default()
+	cfg.Workflows.Start.BaseBranch = "main"
+	cfg.Workflows.Sync.Strategy = "rebase"
+	cfg.Workflows.Sync.AutoPush = true
+	cfg.Workflows.Sync.ForcePush = true
+	cfg.Branches.Protected = []string{"env/(prod|staging)"}
+
+	_, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin"})
+	if err == nil || !strings.Contains(err.Error(), "protected branch env/prod") {
+		t.Fatalf("expected protected branch error, got %v", err)
+	}
+
+	out, err := Sync(cfg, SyncOptions{RepoPath: a, Remote: "origin", StrategyOverride: "merge"})
+	if err != nil {
+		t.Fatalf("Sync with merge: %v", err)
+	}
+	if out.ForcePushed {
+		t.Fatalf("expected no force push with merge strategy")
+	}
+}
@@ -0,0 +1,67 @@
+package workflow
+
+import (
+	"fmt"
+
+	"gitflow/internal/config"
+	"gitflow/internal/git"
+)
+
+// TidyOptions defines inputs for squashing fixup commits.
+type TidyOptions struct {
+	RepoPath string
+}
+
+// TidyResult reports the outcome of an autosquash rebase.
+type TidyResult struct {
+	BaseBranch    string
+	CurrentBranch string
+	Before        int
+	After         int
+}
+
+// Tidy folds fixup and squash commits with an autosquash rebase onto the base.
+func Tidy(cfg *config.Config, opts TidyOptions) (*TidyResult, error) {
+	if opts.RepoPath == "" {
+		return nil, fmt.Errorf("repo path is required")
+	}
+
+	client, err := git.NewClient(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	base := startBaseBranch(cfg)
+
+	current, err := ensureRewritable(client, base)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err = baseRef(client, "origin", base)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := client.CommitsBetween(base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.RebaseAutosquash(base); err != nil {
+		_ = client.RebaseAbort()
+		return nil, fmt.Errorf("autosquash rebase onto %s failed and was aborted: %w", base, err)
+	}
+
+	after, err := client.CommitsBetween(base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	return &TidyResult{
+		BaseBranch:    base,
+		CurrentBranch: current,
+		Before:        len(before),
+		After:         len(after),
+	}, nil
+}
@@ -9,15 +9,18 @@ type PullRequest struct {
 	State       string
 	Author      string
 	HeadBranch  string
-	BaseBranch  string
-	URL         string
-	Draft       bool
+	// HeadSHA is the commit at the head of the pull request.
+	HeadSHA    string
+	BaseBranch string
+	URL        string
+	Draft      bool
+	Merged     bool
 
 	Reviewers []string
 	Labels    []string
 }
 
-// Branch represents a local branch summary.
+// Branch represents a local or remote-tracking branch summary.
 type Branch struct {
 	Name          string
 	Current       bool
@@ -28,6 +31,17 @@ type Branch struct {
 
 	Ahead  int
 	Behind int
+
+	Description string
+	Issue       string
+	Parent      string
+
+	// Remote is set for remote-tracking branches.
+	Remote string
+	Merged bool
+
+	PRNumber int
+	PRState  string
 }
 
 // Release represents a published release.